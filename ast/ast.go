@@ -2,13 +2,17 @@ package ast
 
 import (
 	"bytes"
+	"fmt"
 	"leonardjouve/token"
+	"sort"
 	"strings"
+	"unicode"
 )
 
 type Node interface {
 	TokenLiteral() token.TokenLiteral
 	String() string
+	Span() token.Span
 }
 
 type Statement interface {
@@ -36,6 +40,12 @@ type ExpressionStatement struct {
 }
 
 type LetStatement struct {
+	Token token.Token
+	Name  Expression
+	Value Expression
+}
+
+type ConstStatement struct {
 	Token token.Token
 	Name  *Identifier
 	Value Expression
@@ -51,12 +61,22 @@ type IntegerLiteral struct {
 	Value int64
 }
 
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
 type PrefixExpression struct {
 	Token    token.Token
 	Operator string
 	Right    Expression
 }
 
+type SpreadExpression struct {
+	Token token.Token
+	Value Expression
+}
+
 type InfixExpression struct {
 	Token    token.Token
 	Operator string
@@ -74,6 +94,13 @@ type BlockStatement struct {
 	Statements []Statement
 }
 
+type AssignExpression struct {
+	Token    token.Token
+	Operator string
+	Target   Expression
+	Value    Expression
+}
+
 type IfExpression struct {
 	Token       token.Token
 	Condition   Expression
@@ -81,16 +108,96 @@ type IfExpression struct {
 	Alternative *BlockStatement
 }
 
+type MatchExpression struct {
+	Token   token.Token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+type MatchArm struct {
+	Pattern Expression
+	Guard   Expression
+	Body    Statement
+}
+
+type WhileStatement struct {
+	Token     token.Token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+type ForStatement struct {
+	Token     token.Token
+	Init      Statement
+	Condition Expression
+	Update    Expression
+	Body      *BlockStatement
+}
+
+type ForInStatement struct {
+	Token    token.Token
+	Key      *Identifier
+	Value    *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+type TryStatement struct {
+	Token     token.Token
+	Block     *BlockStatement
+	Parameter *Identifier
+	Catch     *BlockStatement
+	Finally   *BlockStatement
+}
+
+type ThrowStatement struct {
+	Token token.Token
+	Value Expression
+}
+
+type BreakStatement struct {
+	Token token.Token
+}
+
+type ContinueStatement struct {
+	Token token.Token
+}
+
 type FunctionLiteral struct {
 	Token      token.Token
-	Parameters []*Identifier
+	Name       *Identifier
+	Parameters []Expression
 	Body       *BlockStatement
 }
 
+type FunctionStatement struct {
+	Token token.Token
+	Value *FunctionLiteral
+}
+
+type PipelineExpression struct {
+	Token token.Token
+	Left  Expression
+	Right Expression
+}
+
+type DefaultParameter struct {
+	Token token.Token
+	Name  Expression
+	Value Expression
+}
+
 type CallExpression struct {
 	Token     token.Token
 	Function  Expression
 	Arguments []Expression
+	Tail      bool
+}
+
+type NamedArgument struct {
+	Token token.Token
+	Name  *Identifier
+	Value Expression
 }
 
 type StringLiteral struct {
@@ -98,15 +205,36 @@ type StringLiteral struct {
 	Value string
 }
 
+type InterpolatedString struct {
+	Token token.Token
+	Parts []Expression
+}
+
 type ArrayLiteral struct {
 	Token token.Token
 	Value []Expression
 }
 
 type IndexExpression struct {
-	Token token.Token
-	Left  Expression
-	Index Expression
+	Token    token.Token
+	Left     Expression
+	Index    Expression
+	Optional bool
+}
+
+type SliceExpression struct {
+	Token    token.Token
+	Left     Expression
+	Start    Expression
+	End      Expression
+	Optional bool
+}
+
+type ConditionalExpression struct {
+	Token       token.Token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
 }
 
 type HashLiteral struct {
@@ -130,6 +258,12 @@ func (program *Program) String() string {
 	}
 	return out.String()
 }
+func (program *Program) Span() token.Span {
+	if len(program.Statements) == 0 {
+		return token.Span{}
+	}
+	return program.Statements[0].Span().Join(program.Statements[len(program.Statements)-1].Span())
+}
 
 func (identifier *Identifier) expressionNode() {}
 func (identifier *Identifier) TokenLiteral() token.TokenLiteral {
@@ -138,6 +272,9 @@ func (identifier *Identifier) TokenLiteral() token.TokenLiteral {
 func (identifier *Identifier) String() string {
 	return string(identifier.Value)
 }
+func (identifier *Identifier) Span() token.Span {
+	return identifier.Token.Span
+}
 
 func (statement *ExpressionStatement) statementNode() {}
 func (statement *ExpressionStatement) TokenLiteral() token.TokenLiteral {
@@ -149,6 +286,12 @@ func (statement *ExpressionStatement) String() string {
 	}
 	return statement.Value.String()
 }
+func (statement *ExpressionStatement) Span() token.Span {
+	if statement.Value == nil {
+		return statement.Token.Span
+	}
+	return statement.Value.Span()
+}
 
 func (statement *LetStatement) statementNode() {}
 func (statement *LetStatement) TokenLiteral() token.TokenLiteral {
@@ -172,6 +315,35 @@ func (statement *LetStatement) String() string {
 
 	return out.String()
 }
+func (statement *LetStatement) Span() token.Span {
+	return joinSpan(statement.Token.Span, statement.Value)
+}
+
+func (statement *ConstStatement) statementNode() {}
+func (statement *ConstStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *ConstStatement) String() string {
+	var out bytes.Buffer
+
+	constKeyword, ok := token.GetKeywordFromType(token.CONST)
+	if ok {
+		out.WriteString(string(constKeyword) + " ")
+	}
+
+	out.WriteString(statement.Name.String() + " = ")
+
+	if statement.Value != nil {
+		out.WriteString(statement.Value.String())
+	}
+
+	out.WriteByte(';')
+
+	return out.String()
+}
+func (statement *ConstStatement) Span() token.Span {
+	return joinSpan(statement.Token.Span, statement.Value)
+}
 
 func (statement *ReturnStatement) statementNode() {}
 func (statement *ReturnStatement) TokenLiteral() token.TokenLiteral {
@@ -191,6 +363,9 @@ func (statement *ReturnStatement) String() string {
 
 	return out.String()
 }
+func (statement *ReturnStatement) Span() token.Span {
+	return joinSpan(statement.Token.Span, statement.Value)
+}
 
 func (literal *IntegerLiteral) expressionNode() {}
 func (literal *IntegerLiteral) TokenLiteral() token.TokenLiteral {
@@ -199,6 +374,20 @@ func (literal *IntegerLiteral) TokenLiteral() token.TokenLiteral {
 func (literal *IntegerLiteral) String() string {
 	return string(literal.Token.Literal)
 }
+func (literal *IntegerLiteral) Span() token.Span {
+	return literal.Token.Span
+}
+
+func (literal *FloatLiteral) expressionNode() {}
+func (literal *FloatLiteral) TokenLiteral() token.TokenLiteral {
+	return literal.Token.Literal
+}
+func (literal *FloatLiteral) String() string {
+	return string(literal.Token.Literal)
+}
+func (literal *FloatLiteral) Span() token.Span {
+	return literal.Token.Span
+}
 
 func (boolean *Boolean) expressionNode() {}
 func (boolean *Boolean) TokenLiteral() token.TokenLiteral {
@@ -207,6 +396,9 @@ func (boolean *Boolean) TokenLiteral() token.TokenLiteral {
 func (boolean *Boolean) String() string {
 	return string(boolean.Token.Literal)
 }
+func (boolean *Boolean) Span() token.Span {
+	return boolean.Token.Span
+}
 
 func (expression *PrefixExpression) expressionNode() {}
 func (expression *PrefixExpression) TokenLiteral() token.TokenLiteral {
@@ -219,6 +411,20 @@ func (expression *PrefixExpression) String() string {
 
 	return out.String()
 }
+func (expression *PrefixExpression) Span() token.Span {
+	return joinSpan(expression.Token.Span, expression.Right)
+}
+
+func (expression *SpreadExpression) expressionNode() {}
+func (expression *SpreadExpression) TokenLiteral() token.TokenLiteral {
+	return expression.Token.Literal
+}
+func (expression *SpreadExpression) String() string {
+	return string(expression.TokenLiteral()) + expression.Value.String()
+}
+func (expression *SpreadExpression) Span() token.Span {
+	return joinSpan(expression.Token.Span, expression.Value)
+}
 
 func (expression *InfixExpression) expressionNode() {}
 func (expression *InfixExpression) TokenLiteral() token.TokenLiteral {
@@ -231,6 +437,13 @@ func (expression *InfixExpression) String() string {
 
 	return out.String()
 }
+func (expression *InfixExpression) Span() token.Span {
+	span := expression.Token.Span
+	if expression.Left != nil {
+		span = expression.Left.Span()
+	}
+	return joinSpan(span, expression.Right)
+}
 
 func (statement *BlockStatement) statementNode() {}
 func (statement *BlockStatement) TokenLiteral() token.TokenLiteral {
@@ -245,6 +458,31 @@ func (statement *BlockStatement) String() string {
 
 	return out.String()
 }
+func (statement *BlockStatement) Span() token.Span {
+	if len(statement.Statements) == 0 {
+		return statement.Token.Span
+	}
+	return joinSpan(statement.Token.Span, statement.Statements[len(statement.Statements)-1])
+}
+
+func (expression *AssignExpression) expressionNode() {}
+func (expression *AssignExpression) TokenLiteral() token.TokenLiteral {
+	return expression.Token.Literal
+}
+func (expression *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(" + expression.Target.String() + " " + expression.Operator + " " + expression.Value.String() + ")")
+
+	return out.String()
+}
+func (expression *AssignExpression) Span() token.Span {
+	span := expression.Token.Span
+	if expression.Target != nil {
+		span = expression.Target.Span()
+	}
+	return joinSpan(span, expression.Value)
+}
 
 func (expression *IfExpression) expressionNode() {}
 func (expression *IfExpression) TokenLiteral() token.TokenLiteral {
@@ -271,6 +509,249 @@ func (expression *IfExpression) String() string {
 
 	return out.String()
 }
+func (expression *IfExpression) Span() token.Span {
+	if expression.Alternative != nil {
+		return joinSpan(expression.Token.Span, expression.Alternative)
+	}
+	if expression.Consequence != nil {
+		return joinSpan(expression.Token.Span, expression.Consequence)
+	}
+	return joinSpan(expression.Token.Span, expression.Condition)
+}
+
+func (expression *MatchExpression) expressionNode() {}
+func (expression *MatchExpression) TokenLiteral() token.TokenLiteral {
+	return expression.Token.Literal
+}
+func (expression *MatchExpression) String() string {
+	var out bytes.Buffer
+
+	matchKeyword, ok := token.GetKeywordFromType(token.MATCH)
+	if ok {
+		out.WriteString(string(matchKeyword) + " ")
+	}
+
+	arms := []string{}
+	for _, arm := range expression.Arms {
+		arms = append(arms, arm.String())
+	}
+
+	out.WriteString("(" + expression.Subject.String() + ") { " + strings.Join(arms, ", ") + " }")
+
+	return out.String()
+}
+func (expression *MatchExpression) Span() token.Span {
+	if len(expression.Arms) == 0 {
+		return joinSpan(expression.Token.Span, expression.Subject)
+	}
+	return joinSpan(expression.Token.Span, expression.Arms[len(expression.Arms)-1].Body)
+}
+
+func (arm *MatchArm) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(arm.Pattern.String())
+
+	if arm.Guard != nil {
+		ifKeyword, ok := token.GetKeywordFromType(token.IF)
+		if ok {
+			out.WriteString(" " + string(ifKeyword) + " ")
+		}
+		out.WriteString(arm.Guard.String())
+	}
+
+	arrow, ok := token.GetSymbolFromType(token.ARROW)
+	if ok {
+		out.WriteString(" " + string(arrow) + " ")
+	}
+
+	out.WriteString(arm.Body.String())
+
+	return out.String()
+}
+
+func (statement *WhileStatement) statementNode() {}
+func (statement *WhileStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *WhileStatement) String() string {
+	var out bytes.Buffer
+
+	whileKeyword, ok := token.GetKeywordFromType(token.WHILE)
+	if ok {
+		out.WriteString(string(whileKeyword) + " ")
+	}
+
+	out.WriteString(statement.Condition.String() + " " + statement.Body.String())
+
+	return out.String()
+}
+func (statement *WhileStatement) Span() token.Span {
+	if statement.Body == nil {
+		return joinSpan(statement.Token.Span, statement.Condition)
+	}
+	return joinSpan(statement.Token.Span, statement.Body)
+}
+
+func (statement *ForStatement) statementNode() {}
+func (statement *ForStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *ForStatement) String() string {
+	var out bytes.Buffer
+
+	forKeyword, ok := token.GetKeywordFromType(token.FOR)
+	if ok {
+		out.WriteString(string(forKeyword) + " ")
+	}
+
+	out.WriteByte('(')
+	if statement.Init != nil {
+		out.WriteString(strings.TrimSuffix(statement.Init.String(), ";"))
+	}
+	out.WriteString("; ")
+	if statement.Condition != nil {
+		out.WriteString(statement.Condition.String())
+	}
+	out.WriteString("; ")
+	if statement.Update != nil {
+		out.WriteString(statement.Update.String())
+	}
+	out.WriteString(") " + statement.Body.String())
+
+	return out.String()
+}
+func (statement *ForStatement) Span() token.Span {
+	if statement.Body == nil {
+		return statement.Token.Span
+	}
+	return joinSpan(statement.Token.Span, statement.Body)
+}
+
+func (statement *ForInStatement) statementNode() {}
+func (statement *ForInStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *ForInStatement) String() string {
+	var out bytes.Buffer
+
+	forKeyword, ok := token.GetKeywordFromType(token.FOR)
+	if ok {
+		out.WriteString(string(forKeyword) + " ")
+	}
+
+	out.WriteByte('(')
+	if statement.Key != nil {
+		out.WriteString(statement.Key.String() + ", ")
+	}
+	out.WriteString(statement.Value.String())
+
+	inKeyword, ok := token.GetKeywordFromType(token.IN)
+	if ok {
+		out.WriteString(" " + string(inKeyword) + " ")
+	}
+
+	out.WriteString(statement.Iterable.String() + ") " + statement.Body.String())
+
+	return out.String()
+}
+func (statement *ForInStatement) Span() token.Span {
+	if statement.Body == nil {
+		return statement.Token.Span
+	}
+	return joinSpan(statement.Token.Span, statement.Body)
+}
+
+func (statement *TryStatement) statementNode() {}
+func (statement *TryStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *TryStatement) String() string {
+	var out bytes.Buffer
+
+	tryKeyword, ok := token.GetKeywordFromType(token.TRY)
+	if ok {
+		out.WriteString(string(tryKeyword) + " ")
+	}
+	out.WriteString(statement.Block.String())
+
+	if statement.Catch != nil {
+		catchKeyword, ok := token.GetKeywordFromType(token.CATCH)
+		if ok {
+			out.WriteString(" " + string(catchKeyword) + " ")
+		}
+		if statement.Parameter != nil {
+			out.WriteString("(" + statement.Parameter.String() + ") ")
+		}
+		out.WriteString(statement.Catch.String())
+	}
+
+	if statement.Finally != nil {
+		finallyKeyword, ok := token.GetKeywordFromType(token.FINALLY)
+		if ok {
+			out.WriteString(" " + string(finallyKeyword) + " ")
+		}
+		out.WriteString(statement.Finally.String())
+	}
+
+	return out.String()
+}
+func (statement *TryStatement) Span() token.Span {
+	switch {
+	case statement.Finally != nil:
+		return joinSpan(statement.Token.Span, statement.Finally)
+	case statement.Catch != nil:
+		return joinSpan(statement.Token.Span, statement.Catch)
+	case statement.Block != nil:
+		return joinSpan(statement.Token.Span, statement.Block)
+	default:
+		return statement.Token.Span
+	}
+}
+
+func (statement *ThrowStatement) statementNode() {}
+func (statement *ThrowStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *ThrowStatement) String() string {
+	var out bytes.Buffer
+
+	throwKeyword, ok := token.GetKeywordFromType(token.THROW)
+	if ok {
+		out.WriteString(string(throwKeyword) + " ")
+	}
+
+	if statement.Value != nil {
+		out.WriteString(statement.Value.String())
+	}
+
+	return out.String()
+}
+func (statement *ThrowStatement) Span() token.Span {
+	return joinSpan(statement.Token.Span, statement.Value)
+}
+
+func (statement *BreakStatement) statementNode() {}
+func (statement *BreakStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *BreakStatement) String() string {
+	return string(statement.Token.Literal) + ";"
+}
+func (statement *BreakStatement) Span() token.Span {
+	return statement.Token.Span
+}
+
+func (statement *ContinueStatement) statementNode() {}
+func (statement *ContinueStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *ContinueStatement) String() string {
+	return string(statement.Token.Literal) + ";"
+}
+func (statement *ContinueStatement) Span() token.Span {
+	return statement.Token.Span
+}
 
 func (expression *FunctionLiteral) expressionNode() {}
 func (expression *FunctionLiteral) TokenLiteral() token.TokenLiteral {
@@ -289,10 +770,31 @@ func (expression *FunctionLiteral) String() string {
 		out.WriteString(string(functionKeyword) + " ")
 	}
 
+	if expression.Name != nil {
+		out.WriteString(expression.Name.String())
+	}
+
 	out.WriteString("(" + strings.Join(params, ", ") + ") " + expression.Body.String())
 
 	return out.String()
 }
+func (expression *FunctionLiteral) Span() token.Span {
+	if expression.Body == nil {
+		return expression.Token.Span
+	}
+	return joinSpan(expression.Token.Span, expression.Body)
+}
+
+func (statement *FunctionStatement) statementNode() {}
+func (statement *FunctionStatement) TokenLiteral() token.TokenLiteral {
+	return statement.Token.Literal
+}
+func (statement *FunctionStatement) String() string {
+	return statement.Value.String()
+}
+func (statement *FunctionStatement) Span() token.Span {
+	return joinSpan(statement.Token.Span, statement.Value)
+}
 
 func (expression *CallExpression) expressionNode() {}
 func (expression *CallExpression) TokenLiteral() token.TokenLiteral {
@@ -310,13 +812,85 @@ func (expression *CallExpression) String() string {
 
 	return out.String()
 }
+func (expression *CallExpression) Span() token.Span {
+	span := expression.Token.Span
+	if expression.Function != nil {
+		span = expression.Function.Span()
+	}
+	if len(expression.Arguments) == 0 {
+		return span.Join(expression.Token.Span)
+	}
+	return joinSpan(span, expression.Arguments[len(expression.Arguments)-1])
+}
+
+func (expression *PipelineExpression) expressionNode() {}
+func (expression *PipelineExpression) TokenLiteral() token.TokenLiteral {
+	return expression.Token.Literal
+}
+func (expression *PipelineExpression) String() string {
+	return "(" + expression.Left.String() + " " + string(expression.TokenLiteral()) + " " + expression.Right.String() + ")"
+}
+func (expression *PipelineExpression) Span() token.Span {
+	return joinSpan(expression.Left.Span(), expression.Right)
+}
+
+func (expression *DefaultParameter) expressionNode() {}
+func (expression *DefaultParameter) TokenLiteral() token.TokenLiteral {
+	return expression.Token.Literal
+}
+func (expression *DefaultParameter) String() string {
+	return expression.Name.String() + " " + string(expression.TokenLiteral()) + " " + expression.Value.String()
+}
+func (expression *DefaultParameter) Span() token.Span {
+	return joinSpan(expression.Name.Span(), expression.Value)
+}
+
+func (expression *NamedArgument) expressionNode() {}
+func (expression *NamedArgument) TokenLiteral() token.TokenLiteral {
+	return expression.Token.Literal
+}
+func (expression *NamedArgument) String() string {
+	return expression.Name.String() + ": " + expression.Value.String()
+}
+func (expression *NamedArgument) Span() token.Span {
+	return joinSpan(expression.Token.Span, expression.Value)
+}
 
 func (stringLiteral *StringLiteral) expressionNode() {}
 func (stringLiteral *StringLiteral) TokenLiteral() token.TokenLiteral {
 	return stringLiteral.Token.Literal
 }
 func (stringLiteral *StringLiteral) String() string {
-	return string(stringLiteral.Token.Literal)
+	return "\"" + escapeString(stringLiteral.Value) + "\""
+}
+func (stringLiteral *StringLiteral) Span() token.Span {
+	return stringLiteral.Token.Span
+}
+
+func (interpolatedString *InterpolatedString) expressionNode() {}
+func (interpolatedString *InterpolatedString) TokenLiteral() token.TokenLiteral {
+	return interpolatedString.Token.Literal
+}
+func (interpolatedString *InterpolatedString) String() string {
+	var out bytes.Buffer
+
+	out.WriteByte('"')
+	for _, part := range interpolatedString.Parts {
+		if stringLiteral, ok := part.(*StringLiteral); ok {
+			out.WriteString(escapeString(stringLiteral.Value))
+		} else {
+			out.WriteString("${" + part.String() + "}")
+		}
+	}
+	out.WriteByte('"')
+
+	return out.String()
+}
+func (interpolatedString *InterpolatedString) Span() token.Span {
+	if len(interpolatedString.Parts) == 0 {
+		return interpolatedString.Token.Span
+	}
+	return joinSpan(interpolatedString.Token.Span, interpolatedString.Parts[len(interpolatedString.Parts)-1])
 }
 
 func (array *ArrayLiteral) expressionNode() {}
@@ -335,6 +909,12 @@ func (array *ArrayLiteral) String() string {
 
 	return out.String()
 }
+func (array *ArrayLiteral) Span() token.Span {
+	if len(array.Value) == 0 {
+		return array.Token.Span
+	}
+	return joinSpan(array.Token.Span, array.Value[len(array.Value)-1])
+}
 
 func (indexExpression *IndexExpression) expressionNode() {}
 func (indexExpression *IndexExpression) TokenLiteral() token.TokenLiteral {
@@ -343,10 +923,58 @@ func (indexExpression *IndexExpression) TokenLiteral() token.TokenLiteral {
 func (indexExpression *IndexExpression) String() string {
 	var out bytes.Buffer
 
-	out.WriteString("(" + indexExpression.Left.String() + "[" + indexExpression.Index.String() + "])")
+	out.WriteString("(" + indexExpression.Left.String() + string(indexExpression.TokenLiteral()) + indexExpression.Index.String() + "])")
 
 	return out.String()
 }
+func (indexExpression *IndexExpression) Span() token.Span {
+	span := indexExpression.Token.Span
+	if indexExpression.Left != nil {
+		span = indexExpression.Left.Span()
+	}
+	return joinSpan(span, indexExpression.Index)
+}
+
+func (expression *ConditionalExpression) expressionNode() {}
+func (expression *ConditionalExpression) TokenLiteral() token.TokenLiteral {
+	return expression.Token.Literal
+}
+func (expression *ConditionalExpression) String() string {
+	return "(" + expression.Condition.String() + " ? " + expression.Consequence.String() + " : " + expression.Alternative.String() + ")"
+}
+func (expression *ConditionalExpression) Span() token.Span {
+	return joinSpan(expression.Condition.Span(), expression.Alternative)
+}
+
+func (sliceExpression *SliceExpression) expressionNode() {}
+func (sliceExpression *SliceExpression) TokenLiteral() token.TokenLiteral {
+	return sliceExpression.Token.Literal
+}
+func (sliceExpression *SliceExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(" + sliceExpression.Left.String() + string(sliceExpression.TokenLiteral()))
+	if sliceExpression.Start != nil {
+		out.WriteString(sliceExpression.Start.String())
+	}
+	out.WriteByte(':')
+	if sliceExpression.End != nil {
+		out.WriteString(sliceExpression.End.String())
+	}
+	out.WriteString("])")
+
+	return out.String()
+}
+func (sliceExpression *SliceExpression) Span() token.Span {
+	span := sliceExpression.Token.Span
+	if sliceExpression.Left != nil {
+		span = sliceExpression.Left.Span()
+	}
+	if sliceExpression.End != nil {
+		return joinSpan(span, sliceExpression.End)
+	}
+	return joinSpan(span, sliceExpression.Start)
+}
 
 func (hashLiteral *HashLiteral) expressionNode() {}
 func (hashLiteral *HashLiteral) TokenLiteral() token.TokenLiteral {
@@ -355,15 +983,36 @@ func (hashLiteral *HashLiteral) TokenLiteral() token.TokenLiteral {
 func (hashLiteral *HashLiteral) String() string {
 	var out bytes.Buffer
 
+	keys := []Expression{}
+	for key := range hashLiteral.Value {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		left, right := keys[i].Span().Start.Offset, keys[j].Span().Start.Offset
+		if left != right {
+			return left < right
+		}
+		return keys[i].String() < keys[j].String()
+	})
+
 	elements := []string{}
-	for key, value := range hashLiteral.Value {
-		elements = append(elements, key.String()+": "+value.String())
+	for _, key := range keys {
+		elements = append(elements, key.String()+": "+hashLiteral.Value[key].String())
 	}
 
 	out.WriteString("{" + strings.Join(elements, ", ") + "}")
 
 	return out.String()
 }
+func (hashLiteral *HashLiteral) Span() token.Span {
+	span := hashLiteral.Token.Span
+	for _, value := range hashLiteral.Value {
+		if valueSpan := value.Span(); valueSpan.End.Offset > span.End.Offset {
+			span.End = valueSpan.End
+		}
+	}
+	return span
+}
 
 func (macro *MacroLiteral) expressionNode() {}
 func (macro *MacroLiteral) TokenLiteral() token.TokenLiteral {
@@ -377,3 +1026,49 @@ func (macro *MacroLiteral) String() string {
 
 	return string(macro.TokenLiteral()) + "(" + strings.Join(parameters, ", ") + ") " + macro.Body.String()
 }
+func (macro *MacroLiteral) Span() token.Span {
+	if macro.Body == nil {
+		return macro.Token.Span
+	}
+	return joinSpan(macro.Token.Span, macro.Body)
+}
+
+func joinSpan(span token.Span, node Node) token.Span {
+	if node == nil {
+		return span
+	}
+	return span.Join(node.Span())
+}
+
+func escapeString(value string) string {
+	var out bytes.Buffer
+
+	for i, char := range value {
+		switch char {
+		case '\\':
+			out.WriteString("\\\\")
+		case '$':
+			if strings.HasPrefix(value[i+1:], "{") {
+				out.WriteString("\\$")
+			} else {
+				out.WriteRune(char)
+			}
+		case '"':
+			out.WriteString("\\\"")
+		case '\n':
+			out.WriteString("\\n")
+		case '\t':
+			out.WriteString("\\t")
+		case '\r':
+			out.WriteString("\\r")
+		default:
+			if unicode.IsControl(char) {
+				out.WriteString(fmt.Sprintf("\\u{%x}", char))
+			} else {
+				out.WriteRune(char)
+			}
+		}
+	}
+
+	return out.String()
+}
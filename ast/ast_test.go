@@ -41,3 +41,42 @@ func TestString(t *testing.T) {
 		t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test)
 	}
 }
+
+func TestStringLiteralString(t *testing.T) {
+	type StringLiteralTest struct {
+		value    string
+		expected string
+	}
+	tests := []StringLiteralTest{
+		{
+			value:    "hello",
+			expected: `"hello"`,
+		},
+		{
+			value:    "a\"b",
+			expected: `"a\"b"`,
+		},
+		{
+			value:    "line\nnext\ttab\\",
+			expected: `"line\nnext\ttab\\"`,
+		},
+		{
+			value:    "caf\u00e9\x00",
+			expected: `"café\u{0}"`,
+		},
+	}
+
+	for _, test := range tests {
+		stringLiteral := &StringLiteral{
+			Token: token.Token{
+				Type:    token.STRING,
+				Literal: token.TokenLiteral(test.value),
+			},
+			Value: test.value,
+		}
+
+		if received := stringLiteral.String(); received != test.expected {
+			t.Errorf("[Test] Invalid string literal: received %s, expected %s", received, test.expected)
+		}
+	}
+}
@@ -143,7 +143,7 @@ func TestModify(t *testing.T) {
 		},
 		{
 			&FunctionLiteral{
-				Parameters: []*Identifier{},
+				Parameters: []Expression{},
 				Body: &BlockStatement{
 					Statements: []Statement{
 						&ExpressionStatement{Value: one()},
@@ -151,7 +151,7 @@ func TestModify(t *testing.T) {
 				},
 			},
 			&FunctionLiteral{
-				Parameters: []*Identifier{},
+				Parameters: []Expression{},
 				Body: &BlockStatement{
 					Statements: []Statement{
 						&ExpressionStatement{Value: two()},
@@ -159,6 +159,30 @@ func TestModify(t *testing.T) {
 				},
 			},
 		},
+		{
+			&FunctionStatement{
+				Value: &FunctionLiteral{
+					Name:       &Identifier{Value: "f"},
+					Parameters: []Expression{},
+					Body: &BlockStatement{
+						Statements: []Statement{
+							&ExpressionStatement{Value: one()},
+						},
+					},
+				},
+			},
+			&FunctionStatement{
+				Value: &FunctionLiteral{
+					Name:       &Identifier{Value: "f"},
+					Parameters: []Expression{},
+					Body: &BlockStatement{
+						Statements: []Statement{
+							&ExpressionStatement{Value: two()},
+						},
+					},
+				},
+			},
+		},
 		{
 			&ArrayLiteral{
 				Value: []Expression{
@@ -173,6 +197,158 @@ func TestModify(t *testing.T) {
 				},
 			},
 		},
+		{
+			&WhileStatement{
+				Condition: one(),
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: one()},
+					},
+				},
+			},
+			&WhileStatement{
+				Condition: two(),
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: two()},
+					},
+				},
+			},
+		},
+		{
+			&ForStatement{
+				Init:      &ExpressionStatement{Value: one()},
+				Condition: one(),
+				Update:    one(),
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: one()},
+					},
+				},
+			},
+			&ForStatement{
+				Init:      &ExpressionStatement{Value: two()},
+				Condition: two(),
+				Update:    two(),
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: two()},
+					},
+				},
+			},
+		},
+		{
+			&DefaultParameter{Name: &Identifier{Value: "x"}, Value: one()},
+			&DefaultParameter{Name: &Identifier{Value: "x"}, Value: two()},
+		},
+		{
+			&NamedArgument{Name: &Identifier{Value: "x"}, Value: one()},
+			&NamedArgument{Name: &Identifier{Value: "x"}, Value: two()},
+		},
+		{
+			&ConditionalExpression{Condition: one(), Consequence: one(), Alternative: one()},
+			&ConditionalExpression{Condition: two(), Consequence: two(), Alternative: two()},
+		},
+		{
+			&SpreadExpression{Value: one()},
+			&SpreadExpression{Value: two()},
+		},
+		{
+			&MatchExpression{
+				Subject: one(),
+				Arms: []*MatchArm{
+					{
+						Pattern: &Identifier{Value: "x"},
+						Guard:   one(),
+						Body:    &ExpressionStatement{Value: one()},
+					},
+				},
+			},
+			&MatchExpression{
+				Subject: two(),
+				Arms: []*MatchArm{
+					{
+						Pattern: &Identifier{Value: "x"},
+						Guard:   two(),
+						Body:    &ExpressionStatement{Value: two()},
+					},
+				},
+			},
+		},
+		{
+			&ForInStatement{
+				Value:    &Identifier{Value: "x"},
+				Iterable: one(),
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: one()},
+					},
+				},
+			},
+			&ForInStatement{
+				Value:    &Identifier{Value: "x"},
+				Iterable: two(),
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: two()},
+					},
+				},
+			},
+		},
+		{
+			&TryStatement{
+				Block: &BlockStatement{
+					Statements: []Statement{
+						&ThrowStatement{Value: one()},
+					},
+				},
+				Parameter: &Identifier{Value: "e"},
+				Catch: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: one()},
+					},
+				},
+				Finally: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: one()},
+					},
+				},
+			},
+			&TryStatement{
+				Block: &BlockStatement{
+					Statements: []Statement{
+						&ThrowStatement{Value: two()},
+					},
+				},
+				Parameter: &Identifier{Value: "e"},
+				Catch: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: two()},
+					},
+				},
+				Finally: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{Value: two()},
+					},
+				},
+			},
+		},
+		{
+			&InterpolatedString{
+				Parts: []Expression{
+					&StringLiteral{Value: "a"},
+					one(),
+					&StringLiteral{Value: "b"},
+				},
+			},
+			&InterpolatedString{
+				Parts: []Expression{
+					&StringLiteral{Value: "a"},
+					two(),
+					&StringLiteral{Value: "b"},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,74 @@
+package ast
+
+func MarkTailCalls(body *BlockStatement) {
+	markTailBlock(body)
+	markTailReturns(body)
+}
+
+func markTailBlock(block *BlockStatement) {
+	if block == nil || len(block.Statements) == 0 {
+		return
+	}
+	markTailStatement(block.Statements[len(block.Statements)-1])
+}
+
+func markTailStatement(statement Statement) {
+	switch statement := statement.(type) {
+	case *ExpressionStatement:
+		markTailExpression(statement.Value)
+	case *ReturnStatement:
+		markTailExpression(statement.Value)
+	case *BlockStatement:
+		markTailBlock(statement)
+	}
+}
+
+func markTailExpression(expression Expression) {
+	switch expression := expression.(type) {
+	case *CallExpression:
+		expression.Tail = true
+	case *IfExpression:
+		markTailBlock(expression.Consequence)
+		markTailBlock(expression.Alternative)
+	case *ConditionalExpression:
+		markTailExpression(expression.Consequence)
+		markTailExpression(expression.Alternative)
+	case *MatchExpression:
+		for _, arm := range expression.Arms {
+			markTailStatement(arm.Body)
+		}
+	}
+}
+
+func markTailReturns(node Node) {
+	switch node := node.(type) {
+	case *BlockStatement:
+		if node == nil {
+			return
+		}
+		for _, statement := range node.Statements {
+			markTailReturns(statement)
+		}
+	case *ReturnStatement:
+		markTailExpression(node.Value)
+	case *ExpressionStatement:
+		markTailReturns(node.Value)
+	case *LetStatement:
+		markTailReturns(node.Value)
+	case *ConstStatement:
+		markTailReturns(node.Value)
+	case *IfExpression:
+		markTailReturns(node.Consequence)
+		markTailReturns(node.Alternative)
+	case *MatchExpression:
+		for _, arm := range node.Arms {
+			markTailReturns(arm.Body)
+		}
+	case *WhileStatement:
+		markTailReturns(node.Body)
+	case *ForStatement:
+		markTailReturns(node.Body)
+	case *ForInStatement:
+		markTailReturns(node.Body)
+	}
+}
@@ -0,0 +1,120 @@
+package diagnostic
+
+import (
+	"bytes"
+	"fmt"
+	"leonardjouve/token"
+	"strings"
+	"unicode/utf8"
+)
+
+type Severity string
+type Code string
+
+type Fix struct {
+	Message     string     `json:"message"`
+	Span        token.Span `json:"span"`
+	Replacement string     `json:"replacement"`
+}
+
+type Diagnostic struct {
+	Severity Severity          `json:"severity"`
+	Code     Code              `json:"code"`
+	Span     token.Span        `json:"span"`
+	Message  string            `json:"message"`
+	Expected []token.TokenType `json:"expected,omitempty"`
+	Received *token.Token      `json:"received,omitempty"`
+	Fix      *Fix              `json:"fix,omitempty"`
+}
+
+const (
+	ERROR   = "error"
+	WARNING = "warning"
+)
+
+const (
+	INVALID_NEXT_TOKEN   = "E0001"
+	INVALID_PREFIX       = "E0002"
+	INVALID_INTEGER      = "E0003"
+	UNTERMINATED_COMMENT = "E0004"
+	ILLEGAL_CHARACTER    = "E0005"
+	INVALID_FLOAT        = "E0006"
+	UNTERMINATED_STRING  = "E0007"
+	INVALID_ESCAPE       = "E0008"
+	INTEGER_OVERFLOW     = "E0009"
+	INVALID_ASSIGNMENT   = "E0010"
+	OUTSIDE_LOOP         = "E0011"
+	INVALID_PATTERN      = "E0012"
+	INVALID_PARAMETER    = "E0013"
+	INVALID_ARGUMENT     = "E0014"
+	READ_ERROR           = "E0015"
+)
+
+func (diagnostic *Diagnostic) Error() string {
+	return fmt.Sprintf("%s: %s[%s]: %s", diagnostic.Span.Start, diagnostic.Severity, diagnostic.Code, diagnostic.Message)
+}
+
+func (diagnostic *Diagnostic) Render(source string) string {
+	var out bytes.Buffer
+
+	out.WriteString(fmt.Sprintf("%s[%s]: %s\n", diagnostic.Severity, diagnostic.Code, diagnostic.Message))
+	out.WriteString(fmt.Sprintf("  --> %s\n", diagnostic.Span.Start))
+
+	line, lineStart, ok := getLine(source, diagnostic.Span.Start.Offset)
+	if ok {
+		lineNumber := fmt.Sprintf("%d", diagnostic.Span.Start.Line)
+		gutter := strings.Repeat(" ", len(lineNumber))
+
+		out.WriteString(fmt.Sprintf("%s |\n", gutter))
+		out.WriteString(fmt.Sprintf("%s | %s\n", lineNumber, line))
+		out.WriteString(fmt.Sprintf("%s | %s\n", gutter, getUnderline(line, diagnostic.Span, lineStart)))
+	}
+
+	if diagnostic.Fix != nil {
+		out.WriteString(fmt.Sprintf("  = help: %s\n", diagnostic.Fix.Message))
+	}
+
+	return out.String()
+}
+
+func getLine(source string, offset int) (string, int, bool) {
+	if offset < 0 || offset > len(source) {
+		return "", 0, false
+	}
+
+	lineStart := strings.LastIndexByte(source[:offset], '\n') + 1
+	lineEnd := strings.IndexByte(source[offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(source)
+	} else {
+		lineEnd += offset
+	}
+
+	return strings.TrimRight(source[lineStart:lineEnd], "\r"), lineStart, true
+}
+
+func getUnderline(line string, span token.Span, lineStart int) string {
+	start := span.Start.Offset - lineStart
+	end := span.End.Offset - lineStart
+	if end > len(line) {
+		end = len(line)
+	}
+
+	var out bytes.Buffer
+
+	for _, char := range line[:start] {
+		if char == '\t' {
+			out.WriteRune('\t')
+		} else {
+			out.WriteRune(' ')
+		}
+	}
+
+	width := 1
+	if end > start {
+		width = utf8.RuneCountInString(line[start:end])
+	}
+	out.WriteString(strings.Repeat("^", width))
+
+	return out.String()
+}
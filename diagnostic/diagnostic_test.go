@@ -0,0 +1,78 @@
+package diagnostic
+
+import (
+	"encoding/json"
+	"leonardjouve/token"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	source := "let x = 5;\n\tlet y 10;\nlet z = 15;"
+	diagnostic := &Diagnostic{
+		Severity: ERROR,
+		Code:     INVALID_NEXT_TOKEN,
+		Span: token.Span{
+			Start: token.Position{Filename: "test", Offset: 18, Line: 2, Column: 8},
+			End:   token.Position{Filename: "test", Offset: 20, Line: 2, Column: 10},
+		},
+		Message: "Invalid next token type: received INT 10, expected ASSIGN",
+		Fix: &Fix{
+			Message:     "insert \"=\"",
+			Replacement: "=",
+		},
+	}
+	expected := "error[E0001]: Invalid next token type: received INT 10, expected ASSIGN\n" +
+		"  --> test:2:8\n" +
+		"  |\n" +
+		"2 | \tlet y 10;\n" +
+		"  | \t      ^^\n" +
+		"  = help: insert \"=\"\n"
+
+	if rendered := diagnostic.Render(source); rendered != expected {
+		t.Errorf("[Test] Invalid rendered diagnostic: received\n%s\nexpected\n%s", rendered, expected)
+	}
+}
+
+func TestError(t *testing.T) {
+	diagnostic := &Diagnostic{
+		Severity: ERROR,
+		Code:     INVALID_PREFIX,
+		Span: token.Span{
+			Start: token.Position{Offset: 4, Line: 1, Column: 5},
+			End:   token.Position{Offset: 5, Line: 1, Column: 6},
+		},
+		Message: "Invalid prefix for SEMICOLON",
+	}
+	expected := "1:5: error[E0002]: Invalid prefix for SEMICOLON"
+
+	if err := diagnostic.Error(); err != expected {
+		t.Errorf("[Test] Invalid diagnostic error: received %s, expected %s", err, expected)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	diagnostic := &Diagnostic{
+		Severity: ERROR,
+		Code:     INVALID_NEXT_TOKEN,
+		Span: token.Span{
+			Start: token.Position{Offset: 6, Line: 1, Column: 7},
+			End:   token.Position{Offset: 7, Line: 1, Column: 8},
+		},
+		Message:  "Invalid next token type: received INT 5, expected ASSIGN",
+		Expected: []token.TokenType{token.ASSIGN},
+		Received: &token.Token{
+			Type:    token.INT,
+			Literal: "5",
+		},
+	}
+	expected := `{"severity":"error","code":"E0001","span":{"start":{"offset":6,"line":1,"column":7},"end":{"offset":7,"line":1,"column":8}},"message":"Invalid next token type: received INT 5, expected ASSIGN","expected":["ASSIGN"],"received":{"type":"INT","literal":"5","span":{"start":{"offset":0,"line":0,"column":0},"end":{"offset":0,"line":0,"column":0}}}}`
+
+	encoded, err := json.Marshal(diagnostic)
+	if err != nil {
+		t.Fatalf("[Test] Invalid json encoding: %s", err)
+	}
+
+	if string(encoded) != expected {
+		t.Errorf("[Test] Invalid json: received %s, expected %s", encoded, expected)
+	}
+}
@@ -0,0 +1,7 @@
+module leonardjouve/diagnostic
+
+replace leonardjouve/token => ../token
+
+go 1.20
+
+require leonardjouve/token v0.0.0-00010101000000-000000000000
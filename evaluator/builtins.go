@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"leonardjouve/object"
 	"leonardjouve/token"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 var builtins = map[token.TokenLiteral]*object.Builtin{
@@ -19,12 +23,16 @@ var builtins = map[token.TokenLiteral]*object.Builtin{
 			switch argument := arguments[0].(type) {
 			case *object.String:
 				return &object.Integer{
-					Value: int64(len(argument.Value)),
+					Value: int64(utf8.RuneCountInString(argument.Value)),
 				}
 			case *object.Array:
 				return &object.Integer{
 					Value: int64(len(argument.Value)),
 				}
+			case *object.Range:
+				return &object.Integer{
+					Value: argument.Length(),
+				}
 			default:
 				return &object.Error{
 					Value: fmt.Sprintf("unsupported argument for builtin function len: %s", argument.Type()),
@@ -157,6 +165,155 @@ var builtins = map[token.TokenLiteral]*object.Builtin{
 			}
 		},
 	},
+	"int": {
+		Value: func(arguments ...object.Object) object.Object {
+			expectedArgumentAmount := 1
+			if argumentAmout := len(arguments); argumentAmout != expectedArgumentAmount {
+				return &object.Error{
+					Value: fmt.Sprintf("wrong arguments amount: received %d, expected %d", argumentAmout, expectedArgumentAmount),
+				}
+			}
+
+			switch argument := arguments[0].(type) {
+			case *object.Integer:
+				return argument
+			case *object.Float:
+				if !(argument.Value >= math.MinInt64 && argument.Value < math.MaxInt64) {
+					return &object.Error{
+						Value: fmt.Sprintf("invalid argument for builtin function int: %s", argument.Inspect()),
+					}
+				}
+				return &object.Integer{
+					Value: int64(argument.Value),
+				}
+			case *object.String:
+				value, err := strconv.ParseInt(strings.TrimSpace(argument.Value), 0, 64)
+				if err != nil {
+					return &object.Error{
+						Value: fmt.Sprintf("invalid argument for builtin function int: %q", argument.Value),
+					}
+				}
+				return &object.Integer{
+					Value: value,
+				}
+			default:
+				return &object.Error{
+					Value: fmt.Sprintf("unsupported argument for builtin function int: %s", argument.Type()),
+				}
+			}
+		},
+	},
+	"float": {
+		Value: func(arguments ...object.Object) object.Object {
+			expectedArgumentAmount := 1
+			if argumentAmout := len(arguments); argumentAmout != expectedArgumentAmount {
+				return &object.Error{
+					Value: fmt.Sprintf("wrong arguments amount: received %d, expected %d", argumentAmout, expectedArgumentAmount),
+				}
+			}
+
+			switch argument := arguments[0].(type) {
+			case *object.Integer:
+				return &object.Float{
+					Value: float64(argument.Value),
+				}
+			case *object.Float:
+				return argument
+			case *object.String:
+				value, err := strconv.ParseFloat(strings.TrimSpace(argument.Value), 64)
+				if err != nil {
+					return &object.Error{
+						Value: fmt.Sprintf("invalid argument for builtin function float: %q", argument.Value),
+					}
+				}
+				return &object.Float{
+					Value: value,
+				}
+			default:
+				return &object.Error{
+					Value: fmt.Sprintf("unsupported argument for builtin function float: %s", argument.Type()),
+				}
+			}
+		},
+	},
+	"range": {
+		Value: func(arguments ...object.Object) object.Object {
+			minArgumentAmount, maxArgumentAmount := 1, 3
+			if argumentAmout := len(arguments); argumentAmout < minArgumentAmount || argumentAmout > maxArgumentAmount {
+				return &object.Error{
+					Value: fmt.Sprintf("wrong arguments amount: received %d, expected %d to %d", argumentAmout, minArgumentAmount, maxArgumentAmount),
+				}
+			}
+
+			bounds := make([]int64, len(arguments))
+			for i, argument := range arguments {
+				integer, ok := argument.(*object.Integer)
+				if !ok {
+					return &object.Error{
+						Value: fmt.Sprintf("unsupported argument for builtin function range: %s", argument.Type()),
+					}
+				}
+				bounds[i] = integer.Value
+			}
+
+			rng := &object.Range{
+				Start: 0,
+				End:   bounds[0],
+				Step:  1,
+			}
+			if len(bounds) > 1 {
+				rng.Start = bounds[0]
+				rng.End = bounds[1]
+			}
+			if len(bounds) > 2 {
+				rng.Step = bounds[2]
+			}
+
+			if rng.Step == 0 {
+				return &object.Error{
+					Value: "invalid argument for builtin function range: step cannot be 0",
+				}
+			}
+
+			return rng
+		},
+	},
+	"error": {
+		Value: func(arguments ...object.Object) object.Object {
+			minArgumentAmount, maxArgumentAmount := 1, 2
+			if argumentAmout := len(arguments); argumentAmout < minArgumentAmount || argumentAmout > maxArgumentAmount {
+				return &object.Error{
+					Value: fmt.Sprintf("wrong arguments amount: received %d, expected %d to %d", argumentAmout, minArgumentAmount, maxArgumentAmount),
+				}
+			}
+
+			message, ok := arguments[0].(*object.String)
+			if !ok {
+				return &object.Error{
+					Value: fmt.Sprintf("unsupported argument for builtin function error: %s", arguments[0].Type()),
+				}
+			}
+
+			err := &object.Error{
+				Value: message.Value,
+				Kind:  object.ERROR_KIND,
+			}
+
+			if len(arguments) > 1 {
+				cause, ok := arguments[1].(*object.Exception)
+				if !ok {
+					return &object.Error{
+						Value: fmt.Sprintf("unsupported argument for builtin function error: %s", arguments[1].Type()),
+					}
+				}
+				err.Cause = cause.Error
+			}
+
+			return &object.Exception{
+				Error: err,
+			}
+		},
+	},
 	"puts": {
 		Value: func(arguments ...object.Object) object.Object {
 			for _, argument := range arguments {
@@ -1,9 +1,16 @@
 package evaluator
 
 import (
+	"bytes"
 	"fmt"
 	"leonardjouve/ast"
 	"leonardjouve/object"
+	"leonardjouve/token"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 var (
@@ -13,10 +20,31 @@ var (
 	TRUE = &object.Boolean{
 		Value: true,
 	}
-	NULL = &object.Null{}
+	NULL     = &object.Null{}
+	BREAK    = &object.Break{}
+	CONTINUE = &object.Continue{}
 )
 
+var MaxCallDepth = 10000
+
+const maxStackFrames = 20
+
 func Eval(node ast.Node, env *object.Environement) object.Object {
+	obj := evalNode(node, env)
+
+	if err, ok := obj.(*object.Error); ok {
+		if !err.Position.IsValid() {
+			err.Position = node.Span().Start
+		}
+		if len(err.Kind) == 0 {
+			err.Kind = object.RUNTIME_ERROR_KIND
+		}
+	}
+
+	return obj
+}
+
+func evalNode(node ast.Node, env *object.Environement) object.Object {
 	switch node := node.(type) {
 	case *ast.Program:
 		return evalProgram(node.Statements, env)
@@ -28,6 +56,10 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		return &object.Integer{
 			Value: node.Value,
 		}
+	case *ast.FloatLiteral:
+		return &object.Float{
+			Value: node.Value,
+		}
 	case *ast.Boolean:
 		return nativeBoolToBooleanObject(node.Value)
 	case *ast.PrefixExpression:
@@ -36,7 +68,17 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 			return right
 		}
 		return evalPrefixExpression(node.Operator, right)
+	case *ast.SpreadExpression:
+		return &object.Error{
+			Value: fmt.Sprintf("unexpected spread expression: %s", node.String()),
+		}
 	case *ast.InfixExpression:
+		if node.Operator == "??" {
+			return evalNullishExpression(node, env)
+		}
+		if node.Operator == "&&" || node.Operator == "||" {
+			return evalLogicalExpression(node, env)
+		}
 		left := Eval(node.Left, env)
 		if isError(left) {
 			return left
@@ -48,6 +90,22 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		return evalInfixExpression(node.Operator, left, right)
 	case *ast.IfExpression:
 		return evalIfExpression(node, env)
+	case *ast.WhileStatement:
+		return evalWhileStatement(node, env)
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env)
+	case *ast.ForStatement:
+		return evalForStatement(node, env)
+	case *ast.ForInStatement:
+		return evalForInStatement(node, env)
+	case *ast.TryStatement:
+		return evalTryStatement(node, env)
+	case *ast.ThrowStatement:
+		return evalThrowStatement(node, env)
+	case *ast.BreakStatement:
+		return BREAK
+	case *ast.ContinueStatement:
+		return CONTINUE
 	case *ast.ReturnStatement:
 		value := Eval(node.Value, env)
 		if isError(value) {
@@ -61,16 +119,30 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		if isError(value) {
 			return value
 		}
-		env.Set(node.Name.Value, value)
+		if err := bindPattern(node.Name, value, env); err != nil {
+			return err
+		}
+		return value
+	case *ast.ConstStatement:
+		value := Eval(node.Value, env)
+		if isError(value) {
+			return value
+		}
+		if env.IsConstant(node.Name.Value) {
+			return &object.Error{
+				Value: fmt.Sprintf("cannot redeclare constant: %s", node.Name.Value),
+			}
+		}
+		env.SetConstant(node.Name.Value, value)
 		return value
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
+	case *ast.AssignExpression:
+		return evalAssignExpression(node, env)
 	case *ast.FunctionLiteral:
-		return &object.Function{
-			Parameters: node.Parameters,
-			Body:       node.Body,
-			Env:        env,
-		}
+		return evalFunctionLiteral(node, env)
+	case *ast.FunctionStatement:
+		return evalFunctionStatement(node, env)
 	case *ast.CallExpression:
 		if node.Function.TokenLiteral() == "quote" {
 			return quote(node.Arguments[0], env)
@@ -80,16 +152,32 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		if isError(function) {
 			return function
 		}
-		arguments := evalExpressions(node.Arguments, env)
-		if len(arguments) == 1 && isError(arguments[0]) {
-			return arguments[0]
+		arguments, namedArguments, err := evalArguments(node.Arguments, env)
+		if err != nil {
+			return err
+		}
+
+		if userFunction, ok := function.(*object.Function); ok && node.Tail {
+			return &object.TailCall{
+				Function:       userFunction,
+				Arguments:      arguments,
+				NamedArguments: namedArguments,
+				Callee:         node.Function,
+				Position:       node.Span().Start,
+			}
 		}
 
-		return applyFunction(function, arguments)
+		return callFunction(node, node.Function, function, arguments, namedArguments)
+	case *ast.PipelineExpression:
+		return evalPipelineExpression(node, env)
+	case *ast.ConditionalExpression:
+		return evalConditionalExpression(node, env)
 	case *ast.StringLiteral:
 		return &object.String{
 			Value: node.Value,
 		}
+	case *ast.InterpolatedString:
+		return evalInterpolatedString(node.Parts, env)
 	case *ast.ArrayLiteral:
 		elements := evalExpressions(node.Value, env)
 		if len(elements) == 1 && isError(elements[0]) {
@@ -104,11 +192,16 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 		if isError(left) {
 			return left
 		}
+		if node.Optional && left == NULL {
+			return NULL
+		}
 		index := Eval(node.Index, env)
 		if isError(index) {
 			return index
 		}
 		return evalIndexExpression(left, index)
+	case *ast.SliceExpression:
+		return evalSliceExpression(node, env)
 	case *ast.HashLiteral:
 		return evalHashLiteral(node, env)
 	default:
@@ -119,7 +212,15 @@ func Eval(node ast.Node, env *object.Environement) object.Object {
 func evalProgram(statements []ast.Statement, env *object.Environement) object.Object {
 	var obj object.Object
 
+	if err := hoistFunctions(statements, env); err != nil {
+		return err
+	}
+
 	for _, statement := range statements {
+		if _, ok := statement.(*ast.FunctionStatement); ok {
+			continue
+		}
+
 		obj = Eval(statement, env)
 
 		switch obj := obj.(type) {
@@ -136,10 +237,24 @@ func evalProgram(statements []ast.Statement, env *object.Environement) object.Ob
 func evalBlockStatement(node *ast.BlockStatement, env *object.Environement) object.Object {
 	var obj object.Object
 
+	if err := hoistFunctions(node.Statements, env); err != nil {
+		return err
+	}
+
 	for _, statement := range node.Statements {
+		if _, ok := statement.(*ast.FunctionStatement); ok {
+			obj = NULL
+			continue
+		}
+
 		obj = Eval(statement, env)
 
-		if objType := obj.Type(); obj != nil && (objType == object.RETURN || objType == object.ERROR) {
+		if obj == nil {
+			continue
+		}
+
+		switch obj.Type() {
+		case object.RETURN, object.ERROR, object.BREAK, object.CONTINUE:
 			return obj
 		}
 	}
@@ -147,6 +262,70 @@ func evalBlockStatement(node *ast.BlockStatement, env *object.Environement) obje
 	return obj
 }
 
+func hoistFunctions(statements []ast.Statement, env *object.Environement) object.Object {
+	constants := make(map[token.TokenLiteral]bool)
+	for _, statement := range statements {
+		if constant, ok := statement.(*ast.ConstStatement); ok {
+			constants[constant.Name.Value] = true
+		}
+	}
+
+	for _, statement := range statements {
+		function, ok := statement.(*ast.FunctionStatement)
+		if !ok {
+			continue
+		}
+
+		if name := function.Value.Name.Value; constants[name] {
+			return &object.Error{
+				Value:    fmt.Sprintf("cannot redeclare constant: %s", name),
+				Position: function.Span().Start,
+			}
+		}
+
+		if eval := Eval(statement, env); isError(eval) {
+			return eval
+		}
+	}
+
+	return nil
+}
+
+func evalFunctionLiteral(node *ast.FunctionLiteral, env *object.Environement) object.Object {
+	function := &object.Function{
+		Parameters: node.Parameters,
+		Body:       node.Body,
+		Env:        env,
+	}
+
+	if node.Name != nil {
+		function.Name = node.Name.Value
+		function.Env = object.NewEnclosedEnvironement(env)
+		function.Env.Set(node.Name.Value, function)
+	}
+
+	return function
+}
+
+func evalFunctionStatement(node *ast.FunctionStatement, env *object.Environement) object.Object {
+	name := node.Value.Name.Value
+	if env.IsConstant(name) {
+		return &object.Error{
+			Value: fmt.Sprintf("cannot redeclare constant: %s", name),
+		}
+	}
+
+	function := &object.Function{
+		Name:       name,
+		Parameters: node.Value.Parameters,
+		Body:       node.Value.Body,
+		Env:        env,
+	}
+	env.Set(name, function)
+
+	return function
+}
+
 func nativeBoolToBooleanObject(boolean bool) *object.Boolean {
 	if boolean {
 		return TRUE
@@ -181,21 +360,28 @@ func evalBangeOperatorExpression(right object.Object) object.Object {
 }
 
 func evalMinusOperatorExpression(right object.Object) object.Object {
-	if right.Type() != object.INTEGER {
+	switch right := right.(type) {
+	case *object.Integer:
+		return &object.Integer{
+			Value: -right.Value,
+		}
+	case *object.Float:
+		return &object.Float{
+			Value: -right.Value,
+		}
+	default:
 		return &object.Error{
 			Value: fmt.Sprintf("unknown operation: -%s", right.Type()),
 		}
 	}
-
-	return &object.Integer{
-		Value: -right.(*object.Integer).Value,
-	}
 }
 
 func evalInfixExpression(operator string, left object.Object, right object.Object) object.Object {
 	switch {
 	case left.Type() == object.INTEGER && right.Type() == object.INTEGER:
 		return evalIntegerInfixExpression(operator, left, right)
+	case isNumber(left) && isNumber(right):
+		return evalFloatInfixExpression(operator, left, right)
 	case left.Type() == object.STRING && right.Type() == object.STRING:
 		return evalStringInfixExpression(operator, left, right)
 	case operator == "==":
@@ -213,6 +399,45 @@ func evalInfixExpression(operator string, left object.Object, right object.Objec
 	}
 }
 
+func evalNullishExpression(node *ast.InfixExpression, env *object.Environement) object.Object {
+	left := Eval(node.Left, env)
+	if isError(left) || left != NULL {
+		return left
+	}
+
+	return Eval(node.Right, env)
+}
+
+func evalConditionalExpression(node *ast.ConditionalExpression, env *object.Environement) object.Object {
+	condition := Eval(node.Condition, env)
+	if isError(condition) {
+		return condition
+	}
+
+	if isTruthy(condition) {
+		return Eval(node.Consequence, env)
+	}
+	return Eval(node.Alternative, env)
+}
+
+func evalLogicalExpression(node *ast.InfixExpression, env *object.Environement) object.Object {
+	left := Eval(node.Left, env)
+	if isError(left) {
+		return left
+	}
+
+	if isTruthy(left) == (node.Operator == "||") {
+		return nativeBoolToBooleanObject(isTruthy(left))
+	}
+
+	right := Eval(node.Right, env)
+	if isError(right) {
+		return right
+	}
+
+	return nativeBoolToBooleanObject(isTruthy(right))
+}
+
 func evalIntegerInfixExpression(operator string, left object.Object, right object.Object) object.Object {
 	leftValue := left.(*object.Integer).Value
 	rightValue := right.(*object.Integer).Value
@@ -233,13 +458,57 @@ func evalIntegerInfixExpression(operator string, left object.Object, right objec
 			Value: leftValue * rightValue,
 		}
 	case "/":
+		if rightValue == 0 {
+			return &object.Error{
+				Value: fmt.Sprintf("division by zero: %d %s %d", leftValue, operator, rightValue),
+			}
+		}
 		return &object.Integer{
 			Value: leftValue / rightValue,
 		}
+	case "%":
+		if rightValue == 0 {
+			return &object.Error{
+				Value: fmt.Sprintf("division by zero: %d %s %d", leftValue, operator, rightValue),
+			}
+		}
+		return &object.Integer{
+			Value: leftValue % rightValue,
+		}
+	case "&":
+		return &object.Integer{
+			Value: leftValue & rightValue,
+		}
+	case "|":
+		return &object.Integer{
+			Value: leftValue | rightValue,
+		}
+	case "^":
+		return &object.Integer{
+			Value: leftValue ^ rightValue,
+		}
+	case "<<", ">>":
+		if rightValue < 0 {
+			return &object.Error{
+				Value: fmt.Sprintf("negative shift amount: %d %s %d", leftValue, operator, rightValue),
+			}
+		}
+		if operator == "<<" {
+			return &object.Integer{
+				Value: leftValue << rightValue,
+			}
+		}
+		return &object.Integer{
+			Value: leftValue >> rightValue,
+		}
 	case "<":
 		return nativeBoolToBooleanObject(leftValue < rightValue)
 	case ">":
 		return nativeBoolToBooleanObject(leftValue > rightValue)
+	case "<=":
+		return nativeBoolToBooleanObject(leftValue <= rightValue)
+	case ">=":
+		return nativeBoolToBooleanObject(leftValue >= rightValue)
 	case "==":
 		return nativeBoolToBooleanObject(leftValue == rightValue)
 	case "!=":
@@ -251,13 +520,51 @@ func evalIntegerInfixExpression(operator string, left object.Object, right objec
 	}
 }
 
-func evalStringInfixExpression(operator string, left object.Object, right object.Object) object.Object {
-	if operator != "+" {
+func evalFloatInfixExpression(operator string, left object.Object, right object.Object) object.Object {
+	leftValue := toFloat(left)
+	rightValue := toFloat(right)
+
+	switch operator {
+	case "+":
+		return &object.Float{
+			Value: leftValue + rightValue,
+		}
+	case "-":
+		return &object.Float{
+			Value: leftValue - rightValue,
+		}
+	case "*":
+		return &object.Float{
+			Value: leftValue * rightValue,
+		}
+	case "/":
+		return &object.Float{
+			Value: leftValue / rightValue,
+		}
+	case "%":
+		return &object.Float{
+			Value: math.Mod(leftValue, rightValue),
+		}
+	case "<":
+		return nativeBoolToBooleanObject(leftValue < rightValue)
+	case ">":
+		return nativeBoolToBooleanObject(leftValue > rightValue)
+	case "<=":
+		return nativeBoolToBooleanObject(leftValue <= rightValue)
+	case ">=":
+		return nativeBoolToBooleanObject(leftValue >= rightValue)
+	case "==":
+		return nativeBoolToBooleanObject(leftValue == rightValue)
+	case "!=":
+		return nativeBoolToBooleanObject(leftValue != rightValue)
+	default:
 		return &object.Error{
-			Value: fmt.Sprintf("unknown operation: %s %s %s", left.Type(), operator, right.Type()),
+			Value: fmt.Sprintf("unknown operator: %s %s %s", left.Type(), operator, right.Type()),
 		}
 	}
+}
 
+func evalStringInfixExpression(operator string, left object.Object, right object.Object) object.Object {
 	leftValue, ok := left.(*object.String)
 	if !ok {
 		return &object.Error{
@@ -270,8 +577,132 @@ func evalStringInfixExpression(operator string, left object.Object, right object
 			Value: fmt.Sprintf("invalid object type: received %T, expected *object.String", right),
 		}
 	}
-	return &object.String{
-		Value: leftValue.Value + rightValue.Value,
+
+	switch operator {
+	case "+":
+		return &object.String{
+			Value: leftValue.Value + rightValue.Value,
+		}
+	case "<":
+		return nativeBoolToBooleanObject(leftValue.Value < rightValue.Value)
+	case ">":
+		return nativeBoolToBooleanObject(leftValue.Value > rightValue.Value)
+	case "<=":
+		return nativeBoolToBooleanObject(leftValue.Value <= rightValue.Value)
+	case ">=":
+		return nativeBoolToBooleanObject(leftValue.Value >= rightValue.Value)
+	case "==":
+		return nativeBoolToBooleanObject(leftValue.Value == rightValue.Value)
+	case "!=":
+		return nativeBoolToBooleanObject(leftValue.Value != rightValue.Value)
+	default:
+		return &object.Error{
+			Value: fmt.Sprintf("unknown operation: %s %s %s", left.Type(), operator, right.Type()),
+		}
+	}
+}
+
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environement) object.Object {
+	switch target := node.Target.(type) {
+	case *ast.Identifier:
+		scope, ok := env.Resolve(target.Value)
+		if !ok {
+			return &object.Error{
+				Value: fmt.Sprintf("identifier not found: %s", target.String()),
+			}
+		}
+		if scope.IsConstant(target.Value) {
+			return &object.Error{
+				Value: fmt.Sprintf("cannot assign to constant: %s", target.String()),
+			}
+		}
+
+		value := Eval(node.Value, env)
+		if isError(value) {
+			return value
+		}
+
+		if node.Operator != "=" {
+			current := evalIdentifier(target, env)
+			if isError(current) {
+				return current
+			}
+			value = evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, value)
+			if isError(value) {
+				return value
+			}
+		}
+
+		scope.Set(target.Value, value)
+		return value
+	case *ast.IndexExpression:
+		left := Eval(target.Left, env)
+		if isError(left) {
+			return left
+		}
+		index := Eval(target.Index, env)
+		if isError(index) {
+			return index
+		}
+		value := Eval(node.Value, env)
+		if isError(value) {
+			return value
+		}
+
+		if node.Operator != "=" {
+			current := evalIndexExpression(left, index)
+			if isError(current) {
+				return current
+			}
+			value = evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, value)
+			if isError(value) {
+				return value
+			}
+		}
+
+		return evalIndexAssignment(left, index, value)
+	default:
+		return &object.Error{
+			Value: fmt.Sprintf("invalid assignment target: %s", node.Target.String()),
+		}
+	}
+}
+
+func evalIndexAssignment(left object.Object, index object.Object, value object.Object) object.Object {
+	switch left := left.(type) {
+	case *object.Array:
+		i, ok := index.(*object.Integer)
+		if !ok {
+			return &object.Error{
+				Value: fmt.Sprintf("unsupported index assignment: %s[%s]", left.Type(), index.Type()),
+			}
+		}
+
+		if i.Value < 0 || i.Value >= int64(len(left.Value)) {
+			return &object.Error{
+				Value: fmt.Sprintf("index out of range: %d", i.Value),
+			}
+		}
+
+		left.Value[i.Value] = value
+		return value
+	case *object.Hash:
+		key, ok := index.(object.Hashable)
+		if !ok {
+			return &object.Error{
+				Value: fmt.Sprintf("object is not hashable: %s", index.Type()),
+			}
+		}
+
+		left.Value[key.HashKey()] = object.HashPair{
+			Key:   index,
+			Value: value,
+		}
+		return value
+	default:
+		return &object.Error{
+			Value: fmt.Sprintf("unsupported index assignment: %s", left.Type()),
+		}
 	}
 }
 
@@ -291,41 +722,414 @@ func evalIfExpression(ifExpression *ast.IfExpression, env *object.Environement)
 	}
 }
 
-func evalIdentifier(identifier *ast.Identifier, env *object.Environement) object.Object {
-	value, ok := env.Get(identifier.Value)
-	if ok {
-		return value
-	}
-
-	builtin, ok := builtins[identifier.Value]
-	if ok {
-		return builtin
+func evalMatchExpression(node *ast.MatchExpression, env *object.Environement) object.Object {
+	subject := Eval(node.Subject, env)
+	if isError(subject) {
+		return subject
 	}
 
-	return &object.Error{
-		Value: fmt.Sprintf("identifier not found: %s", identifier.String()),
-	}
-}
+	for _, arm := range node.Arms {
+		armEnv := object.NewEnclosedEnvironement(env)
+		if err := bindPattern(arm.Pattern, subject, armEnv); err != nil {
+			continue
+		}
 
-func evalExpressions(expressions []ast.Expression, env *object.Environement) []object.Object {
-	var exps []object.Object
+		if arm.Guard != nil {
+			guard := Eval(arm.Guard, armEnv)
+			if isError(guard) {
+				return guard
+			}
 
-	for _, exp := range expressions {
-		eval := Eval(exp, env)
-		if isError(eval) {
-			return []object.Object{
-				eval,
+			if !isTruthy(guard) {
+				continue
 			}
 		}
-		exps = append(exps, eval)
+
+		return Eval(arm.Body, armEnv)
 	}
 
-	return exps
+	return &object.Error{
+		Value: fmt.Sprintf("no match for value: %s", subject.Inspect()),
+	}
 }
 
-func evalHashLiteral(node *ast.HashLiteral, env *object.Environement) object.Object {
-	elements := make(map[object.HashKey]object.HashPair)
-
+func bindPattern(pattern ast.Expression, value object.Object, env *object.Environement) *object.Error {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		if env.IsConstant(pattern.Value) {
+			return &object.Error{
+				Value: fmt.Sprintf("cannot redeclare constant: %s", pattern.Value),
+			}
+		}
+		env.Set(pattern.Value, value)
+		return nil
+	case *ast.ArrayLiteral:
+		return bindArrayPattern(pattern, value, env)
+	case *ast.HashLiteral:
+		return bindHashPattern(pattern, value, env)
+	default:
+		literal := Eval(pattern, env)
+		if err, ok := literal.(*object.Error); ok {
+			return err
+		}
+
+		if evalInfixExpression("==", literal, value) != TRUE {
+			return &object.Error{
+				Value: fmt.Sprintf("pattern mismatch: received %s, expected %s", value.Inspect(), literal.Inspect()),
+			}
+		}
+		return nil
+	}
+}
+
+func bindArrayPattern(pattern *ast.ArrayLiteral, value object.Object, env *object.Environement) *object.Error {
+	array, ok := value.(*object.Array)
+	if !ok {
+		return &object.Error{
+			Value: fmt.Sprintf("cannot destructure %s as %s", value.Type(), object.ARRAY),
+		}
+	}
+
+	elements := pattern.Value
+	var rest *ast.SpreadExpression
+	if length := len(elements); length > 0 {
+		if spread, ok := elements[length-1].(*ast.SpreadExpression); ok {
+			rest = spread
+			elements = elements[:length-1]
+		}
+	}
+
+	if elementAmount, expectedElementAmount := len(array.Value), len(elements); rest == nil && elementAmount != expectedElementAmount {
+		return &object.Error{
+			Value: fmt.Sprintf("wrong elements amount: received %d, expected %d", elementAmount, expectedElementAmount),
+		}
+	} else if rest != nil && elementAmount < expectedElementAmount {
+		return &object.Error{
+			Value: fmt.Sprintf("wrong elements amount: received %d, expected at least %d", elementAmount, expectedElementAmount),
+		}
+	}
+
+	for i, element := range elements {
+		if err := bindPattern(element, array.Value[i], env); err != nil {
+			return err
+		}
+	}
+
+	if rest != nil {
+		remaining := make([]object.Object, len(array.Value)-len(elements))
+		copy(remaining, array.Value[len(elements):])
+
+		return bindPattern(rest.Value, &object.Array{Value: remaining}, env)
+	}
+
+	return nil
+}
+
+func bindHashPattern(pattern *ast.HashLiteral, value object.Object, env *object.Environement) *object.Error {
+	hash, ok := value.(*object.Hash)
+	if !ok {
+		return &object.Error{
+			Value: fmt.Sprintf("cannot destructure %s as %s", value.Type(), object.HASH),
+		}
+	}
+
+	for keyPattern, valuePattern := range pattern.Value {
+		key := Eval(keyPattern, env)
+		if err, ok := key.(*object.Error); ok {
+			return err
+		}
+		hashable, ok := key.(object.Hashable)
+		if !ok {
+			return &object.Error{
+				Value: fmt.Sprintf("object is not hashable: %s", key.Type()),
+			}
+		}
+
+		pair, ok := hash.Value[hashable.HashKey()]
+		if !ok {
+			return &object.Error{
+				Value: fmt.Sprintf("missing hash key: %s", key.Inspect()),
+			}
+		}
+
+		if err := bindPattern(valuePattern, pair.Value, env); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func evalWhileStatement(node *ast.WhileStatement, env *object.Environement) object.Object {
+	for {
+		condition := Eval(node.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+
+		if !isTruthy(condition) {
+			return NULL
+		}
+
+		result := Eval(node.Body, object.NewEnclosedEnvironement(env))
+		if result, done := evalLoopSignal(result); done {
+			return result
+		}
+	}
+}
+
+func evalForStatement(node *ast.ForStatement, env *object.Environement) object.Object {
+	loopEnv := object.NewEnclosedEnvironement(env)
+
+	if node.Init != nil {
+		init := Eval(node.Init, loopEnv)
+		if isError(init) {
+			return init
+		}
+	}
+
+	for {
+		if node.Condition != nil {
+			condition := Eval(node.Condition, loopEnv)
+			if isError(condition) {
+				return condition
+			}
+
+			if !isTruthy(condition) {
+				return NULL
+			}
+		}
+
+		result := Eval(node.Body, object.NewEnclosedEnvironement(loopEnv))
+		if result, done := evalLoopSignal(result); done {
+			return result
+		}
+
+		if node.Update != nil {
+			update := Eval(node.Update, loopEnv)
+			if isError(update) {
+				return update
+			}
+		}
+	}
+}
+
+func evalForInStatement(node *ast.ForInStatement, env *object.Environement) object.Object {
+	iterable := Eval(node.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+
+	source, ok := iterable.(object.Iterable)
+	if !ok {
+		return &object.Error{
+			Value: fmt.Sprintf("object is not iterable: %s", iterable.Type()),
+		}
+	}
+
+	_, isHash := iterable.(*object.Hash)
+	iterator := source.Iterator()
+	for {
+		key, value, ok := iterator.Next()
+		if !ok {
+			return NULL
+		}
+
+		iterationEnv := object.NewEnclosedEnvironement(env)
+		if node.Key != nil {
+			iterationEnv.Set(node.Key.Value, key)
+			iterationEnv.Set(node.Value.Value, value)
+		} else if isHash {
+			iterationEnv.Set(node.Value.Value, key)
+		} else {
+			iterationEnv.Set(node.Value.Value, value)
+		}
+
+		result := Eval(node.Body, iterationEnv)
+		if result, done := evalLoopSignal(result); done {
+			return result
+		}
+	}
+}
+
+func evalTryStatement(node *ast.TryStatement, env *object.Environement) object.Object {
+	result := Eval(node.Block, env)
+
+	if err, ok := result.(*object.Error); ok && node.Catch != nil {
+		catchEnv := object.NewEnclosedEnvironement(env)
+		if node.Parameter != nil {
+			catchEnv.Set(node.Parameter.Value, errorToObject(err))
+		}
+		result = Eval(node.Catch, catchEnv)
+	}
+
+	if node.Finally != nil {
+		finally := Eval(node.Finally, env)
+		if finally != nil {
+			switch finally.Type() {
+			case object.RETURN, object.ERROR, object.BREAK, object.CONTINUE:
+				return finally
+			}
+		}
+	}
+
+	return result
+}
+
+func evalThrowStatement(node *ast.ThrowStatement, env *object.Environement) object.Object {
+	value := Eval(node.Value, env)
+	if isError(value) {
+		return value
+	}
+
+	if exception, ok := value.(*object.Exception); ok {
+		err := *exception.Error
+		err.Position = token.Position{}
+		err.Stack = nil
+		err.OmittedFrames = 0
+		return &err
+	}
+
+	return &object.Error{
+		Value:  value.Inspect(),
+		Kind:   object.ERROR_KIND,
+		Thrown: value,
+	}
+}
+
+func errorToObject(err *object.Error) object.Object {
+	if err.Thrown != nil {
+		return err.Thrown
+	}
+	return &object.Exception{
+		Error: err,
+	}
+}
+
+func evalLoopSignal(result object.Object) (object.Object, bool) {
+	if result == nil {
+		return nil, false
+	}
+
+	switch result.Type() {
+	case object.BREAK:
+		return NULL, true
+	case object.RETURN, object.ERROR:
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+func evalIdentifier(identifier *ast.Identifier, env *object.Environement) object.Object {
+	value, ok := env.Get(identifier.Value)
+	if ok {
+		return value
+	}
+
+	builtin, ok := builtins[identifier.Value]
+	if ok {
+		return builtin
+	}
+
+	return &object.Error{
+		Value: fmt.Sprintf("identifier not found: %s", identifier.String()),
+	}
+}
+
+func evalExpressions(expressions []ast.Expression, env *object.Environement) []object.Object {
+	var exps []object.Object
+
+	for _, exp := range expressions {
+		eval := Eval(exp, env)
+		if isError(eval) {
+			return []object.Object{
+				eval,
+			}
+		}
+		exps = append(exps, eval)
+	}
+
+	return exps
+}
+
+func evalPipelineExpression(node *ast.PipelineExpression, env *object.Environement) object.Object {
+	value := Eval(node.Left, env)
+	if isError(value) {
+		return value
+	}
+
+	callExpression, ok := node.Right.(*ast.CallExpression)
+	if !ok {
+		function := Eval(node.Right, env)
+		if isError(function) {
+			return function
+		}
+
+		return callFunction(node, node.Right, function, []object.Object{value}, nil)
+	}
+
+	function := Eval(callExpression.Function, env)
+	if isError(function) {
+		return function
+	}
+	arguments, namedArguments, err := evalArguments(callExpression.Arguments, env)
+	if err != nil {
+		return err
+	}
+
+	return callFunction(node, callExpression.Function, function, append([]object.Object{value}, arguments...), namedArguments)
+}
+
+func evalArguments(expressions []ast.Expression, env *object.Environement) ([]object.Object, map[token.TokenLiteral]object.Object, object.Object) {
+	arguments := []object.Object{}
+	var namedArguments map[token.TokenLiteral]object.Object
+
+	for _, expression := range expressions {
+		switch expression := expression.(type) {
+		case *ast.SpreadExpression:
+			eval := Eval(expression.Value, env)
+			if isError(eval) {
+				return nil, nil, eval
+			}
+
+			array, ok := eval.(*object.Array)
+			if !ok {
+				return nil, nil, &object.Error{
+					Value: fmt.Sprintf("cannot spread %s", eval.Type()),
+				}
+			}
+			arguments = append(arguments, array.Value...)
+		case *ast.NamedArgument:
+			eval := Eval(expression.Value, env)
+			if isError(eval) {
+				return nil, nil, eval
+			}
+
+			if namedArguments == nil {
+				namedArguments = make(map[token.TokenLiteral]object.Object)
+			}
+			if _, ok := namedArguments[expression.Name.Value]; ok {
+				return nil, nil, &object.Error{
+					Value: fmt.Sprintf("duplicate argument: %s", expression.Name.Value),
+				}
+			}
+			namedArguments[expression.Name.Value] = eval
+		default:
+			eval := Eval(expression, env)
+			if isError(eval) {
+				return nil, nil, eval
+			}
+			arguments = append(arguments, eval)
+		}
+	}
+
+	return arguments, namedArguments, nil
+}
+
+func evalHashLiteral(node *ast.HashLiteral, env *object.Environement) object.Object {
+	elements := make(map[object.HashKey]object.HashPair)
+
 	for keyObject, valueObject := range node.Value {
 		key := Eval(keyObject, env)
 		if isError(key) {
@@ -360,8 +1164,12 @@ func evalIndexExpression(left object.Object, index object.Object) object.Object
 	switch {
 	case left.Type() == object.ARRAY && index.Type() == object.INTEGER:
 		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.STRING && index.Type() == object.INTEGER:
+		return evalStringIndexExpression(left, index)
 	case left.Type() == object.HASH:
 		return evalHashIndexExpression(left, index)
+	case left.Type() == object.EXCEPTION && index.Type() == object.STRING:
+		return evalExceptionIndexExpression(left, index)
 	default:
 		return &object.Error{
 			Value: fmt.Sprintf("unsupported index operation: %s", left.Type()),
@@ -392,6 +1200,106 @@ func evalArrayIndexExpression(array object.Object, index object.Object) object.O
 	return arr.Value[idx]
 }
 
+func evalStringIndexExpression(str object.Object, index object.Object) object.Object {
+	stringObject, ok := str.(*object.String)
+	if !ok {
+		return &object.Error{
+			Value: fmt.Sprintf("invalid object type: received %T, expected *object.String", str),
+		}
+	}
+	i, ok := index.(*object.Integer)
+	if !ok {
+		return &object.Error{
+			Value: fmt.Sprintf("invalid object type: received %T, expected *object.Integer", index),
+		}
+	}
+	runes := []rune(stringObject.Value)
+	idx := i.Value
+	max := int64(len(runes) - 1)
+
+	if idx < 0 || idx > max {
+		return NULL
+	}
+
+	return &object.String{
+		Value: string(runes[idx]),
+	}
+}
+
+func evalSliceExpression(node *ast.SliceExpression, env *object.Environement) object.Object {
+	left := Eval(node.Left, env)
+	if isError(left) {
+		return left
+	}
+	if node.Optional && left == NULL {
+		return NULL
+	}
+
+	var length int64
+	switch left := left.(type) {
+	case *object.Array:
+		length = int64(len(left.Value))
+	case *object.String:
+		length = int64(utf8.RuneCountInString(left.Value))
+	default:
+		return &object.Error{
+			Value: fmt.Sprintf("unsupported slice operation: %s", left.Type()),
+		}
+	}
+
+	start, err := evalSliceBound(node.Start, 0, length, env)
+	if err != nil {
+		return err
+	}
+	end, err := evalSliceBound(node.End, length, length, env)
+	if err != nil {
+		return err
+	}
+	if start > end {
+		start = end
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		elements := make([]object.Object, end-start)
+		copy(elements, left.Value[start:end])
+		return &object.Array{
+			Value: elements,
+		}
+	default:
+		return &object.String{
+			Value: string([]rune(left.(*object.String).Value)[start:end]),
+		}
+	}
+}
+
+func evalSliceBound(bound ast.Expression, fallback int64, length int64, env *object.Environement) (int64, object.Object) {
+	if bound == nil {
+		return fallback, nil
+	}
+
+	value := Eval(bound, env)
+	if isError(value) {
+		return 0, value
+	}
+
+	integer, ok := value.(*object.Integer)
+	if !ok {
+		return 0, &object.Error{
+			Value: fmt.Sprintf("unsupported slice index: %s", value.Type()),
+		}
+	}
+
+	switch {
+	case integer.Value < 0:
+		return 0, nil
+	case integer.Value > length:
+		return length, nil
+	default:
+		return integer.Value, nil
+	}
+}
+
 func evalHashIndexExpression(hash object.Object, index object.Object) object.Object {
 	hashObject, ok := hash.(*object.Hash)
 	if !ok {
@@ -415,13 +1323,152 @@ func evalHashIndexExpression(hash object.Object, index object.Object) object.Obj
 	return element.Value
 }
 
-func applyFunction(function object.Object, arguments []object.Object) object.Object {
+func evalExceptionIndexExpression(exception object.Object, index object.Object) object.Object {
+	exceptionObject, ok := exception.(*object.Exception)
+	if !ok {
+		return &object.Error{
+			Value: fmt.Sprintf("invalid object type: received %s, expected *object.Exception", exception.Type()),
+		}
+	}
+	err := exceptionObject.Error
+
+	switch index.(*object.String).Value {
+	case "message":
+		return &object.String{
+			Value: err.Value,
+		}
+	case "kind":
+		return &object.String{
+			Value: err.Kind,
+		}
+	case "location":
+		if !err.Position.IsValid() {
+			return NULL
+		}
+		return &object.String{
+			Value: err.Position.String(),
+		}
+	case "traceback":
+		return &object.String{
+			Value: err.Traceback(),
+		}
+	case "cause":
+		if err.Cause == nil {
+			return NULL
+		}
+		return errorToObject(err.Cause)
+	default:
+		return NULL
+	}
+}
+
+func callFunction(node ast.Node, callee ast.Expression, function object.Object, arguments []object.Object, namedArguments map[token.TokenLiteral]object.Object) object.Object {
+	result := applyFunction(function, arguments, namedArguments)
+
+	if err, ok := result.(*object.Error); ok {
+		recordFrame(err, callee, function, arguments, namedArguments, node.Span().Start)
+	}
+
+	return result
+}
+
+func recordFrame(err *object.Error, callee ast.Expression, function object.Object, arguments []object.Object, namedArguments map[token.TokenLiteral]object.Object, position token.Position) {
+	if len(err.Stack) >= maxStackFrames {
+		err.OmittedFrames += 1
+		return
+	}
+
+	err.Stack = append(err.Stack, object.Frame{
+		Function:  calleeName(callee, function),
+		Arguments: summarizeArguments(arguments, namedArguments),
+		Position:  position,
+	})
+}
+
+func calleeName(callee ast.Expression, function object.Object) string {
+	if function, ok := function.(*object.Function); ok && len(function.Name) > 0 {
+		return string(function.Name)
+	}
+
+	switch callee := callee.(type) {
+	case *ast.Identifier:
+		return string(callee.Value)
+	case *ast.FunctionLiteral:
+		return "<anonymous>"
+	default:
+		return callee.String()
+	}
+}
+
+func summarizeArguments(arguments []object.Object, namedArguments map[token.TokenLiteral]object.Object) string {
+	summaries := []string{}
+	for _, argument := range arguments {
+		summaries = append(summaries, summarizeObject(argument))
+	}
+
+	names := []string{}
+	for name := range namedArguments {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		summaries = append(summaries, name+": "+summarizeObject(namedArguments[token.TokenLiteral(name)]))
+	}
+
+	return strings.Join(summaries, ", ")
+}
+
+func summarizeObject(obj object.Object) string {
+	maxLength := 20
+
+	switch obj.Type() {
+	case object.FUNCTION, object.BUILTIN:
+		return "fn"
+	case object.STRING:
+		summary := []rune(strconv.Quote(obj.Inspect()))
+		if len(summary) > maxLength {
+			return string(summary[:maxLength-4]) + "...\""
+		}
+		return string(summary)
+	default:
+		summary := []rune(obj.Inspect())
+		if len(summary) > maxLength {
+			return string(summary[:maxLength-3]) + "..."
+		}
+		return string(summary)
+	}
+}
+
+func applyFunction(function object.Object, arguments []object.Object, namedArguments map[token.TokenLiteral]object.Object) object.Object {
 	switch function := function.(type) {
 	case *object.Function:
-		extendedEnv := extendFunctionEnvironement(function, arguments)
-		eval := Eval(function.Body, extendedEnv)
-		return unwrapReturnValue(eval)
+		env := function.Env
+		if env.CallDepth() >= MaxCallDepth {
+			return &object.Error{
+				Value: "maximum recursion depth exceeded",
+			}
+		}
+		env.EnterCall()
+		defer env.LeaveCall()
+
+		result := evalFunctionBody(function, arguments, namedArguments)
+		for {
+			tailCall, ok := result.(*object.TailCall)
+			if !ok {
+				return result
+			}
+
+			result = evalFunctionBody(tailCall.Function, tailCall.Arguments, tailCall.NamedArguments)
+			if err, ok := result.(*object.Error); ok {
+				recordFrame(err, tailCall.Callee, tailCall.Function, tailCall.Arguments, tailCall.NamedArguments, tailCall.Position)
+			}
+		}
 	case *object.Builtin:
+		if len(namedArguments) > 0 {
+			return &object.Error{
+				Value: "unsupported named arguments for builtin function",
+			}
+		}
 		return function.Value(arguments...)
 	default:
 		return &object.Error{
@@ -430,14 +1477,162 @@ func applyFunction(function object.Object, arguments []object.Object) object.Obj
 	}
 }
 
-func extendFunctionEnvironement(function *object.Function, arguments []object.Object) *object.Environement {
+func evalFunctionBody(function *object.Function, arguments []object.Object, namedArguments map[token.TokenLiteral]object.Object) object.Object {
+	extendedEnv, err := extendFunctionEnvironement(function, arguments, namedArguments)
+	if err != nil {
+		return err
+	}
+	eval := Eval(function.Body, extendedEnv)
+	return unwrapReturnValue(eval)
+}
+
+func extendFunctionEnvironement(function *object.Function, arguments []object.Object, namedArguments map[token.TokenLiteral]object.Object) (*object.Environement, *object.Error) {
 	enclosedEnv := object.NewEnclosedEnvironement(function.Env)
 
-	for i, parameter := range function.Parameters {
-		enclosedEnv.Set(parameter.Value, arguments[i])
+	parameters := function.Parameters
+	var rest *ast.SpreadExpression
+	if length := len(parameters); length > 0 {
+		if spread, ok := parameters[length-1].(*ast.SpreadExpression); ok {
+			rest = spread
+			parameters = parameters[:length-1]
+		}
+	}
+
+	if err := checkUnknownArguments(parameters, namedArguments); err != nil {
+		return nil, err
+	}
+
+	if err := checkArity(parameters, rest != nil, len(arguments)+len(namedArguments)); err != nil {
+		return nil, err
+	}
+
+	for i, parameter := range parameters {
+		var defaultValue ast.Expression
+		if defaultParameter, ok := parameter.(*ast.DefaultParameter); ok {
+			parameter = defaultParameter.Name
+			defaultValue = defaultParameter.Value
+		}
+
+		var namedValue object.Object
+		if identifier, ok := parameter.(*ast.Identifier); ok {
+			namedValue = namedArguments[identifier.Value]
+		}
+
+		var value object.Object
+		switch {
+		case i < len(arguments):
+			if namedValue != nil {
+				return nil, &object.Error{
+					Value: fmt.Sprintf("duplicate argument: %s", parameter.String()),
+				}
+			}
+			value = arguments[i]
+		case namedValue != nil:
+			value = namedValue
+		case defaultValue != nil:
+			value = Eval(defaultValue, enclosedEnv)
+			if err, ok := value.(*object.Error); ok {
+				return nil, err
+			}
+		default:
+			return nil, &object.Error{
+				Value: fmt.Sprintf("missing argument: %s", parameter.String()),
+			}
+		}
+
+		if err := bindPattern(parameter, value, enclosedEnv); err != nil {
+			return nil, err
+		}
+	}
+
+	if rest != nil {
+		remaining := []object.Object{}
+		if len(arguments) > len(parameters) {
+			remaining = make([]object.Object, len(arguments)-len(parameters))
+			copy(remaining, arguments[len(parameters):])
+		}
+
+		if err := bindPattern(rest.Value, &object.Array{Value: remaining}, enclosedEnv); err != nil {
+			return nil, err
+		}
+	}
+
+	return enclosedEnv, nil
+}
+
+func checkArity(parameters []ast.Expression, variadic bool, argumentAmount int) *object.Error {
+	requiredAmount := 0
+	for _, parameter := range parameters {
+		if _, ok := parameter.(*ast.DefaultParameter); !ok {
+			requiredAmount += 1
+		}
+	}
+
+	var expected string
+	switch {
+	case variadic:
+		if argumentAmount >= requiredAmount {
+			return nil
+		}
+		expected = fmt.Sprintf("at least %d", requiredAmount)
+	case requiredAmount == len(parameters):
+		if argumentAmount == requiredAmount {
+			return nil
+		}
+		expected = fmt.Sprintf("%d", requiredAmount)
+	default:
+		if argumentAmount >= requiredAmount && argumentAmount <= len(parameters) {
+			return nil
+		}
+		expected = fmt.Sprintf("%d to %d", requiredAmount, len(parameters))
 	}
 
-	return enclosedEnv
+	return &object.Error{
+		Value: fmt.Sprintf("wrong arguments amount: received %d, expected %s", argumentAmount, expected),
+	}
+}
+
+func checkUnknownArguments(parameters []ast.Expression, namedArguments map[token.TokenLiteral]object.Object) *object.Error {
+	names := map[token.TokenLiteral]bool{}
+	for _, parameter := range parameters {
+		if defaultParameter, ok := parameter.(*ast.DefaultParameter); ok {
+			parameter = defaultParameter.Name
+		}
+		if identifier, ok := parameter.(*ast.Identifier); ok {
+			names[identifier.Value] = true
+		}
+	}
+
+	unknown := []string{}
+	for name := range namedArguments {
+		if !names[name] {
+			unknown = append(unknown, string(name))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	return &object.Error{
+		Value: fmt.Sprintf("unknown argument: %s", strings.Join(unknown, ", ")),
+	}
+}
+
+func isNumber(obj object.Object) bool {
+	objType := obj.Type()
+	return objType == object.INTEGER || objType == object.FLOAT
+}
+
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.Float:
+		return obj.Value
+	default:
+		return 0
+	}
 }
 
 func isTruthy(obj object.Object) bool {
@@ -456,3 +1651,19 @@ func unwrapReturnValue(obj object.Object) object.Object {
 
 	return returnObject.Value
 }
+
+func evalInterpolatedString(parts []ast.Expression, env *object.Environement) object.Object {
+	var out bytes.Buffer
+
+	for _, part := range parts {
+		value := Eval(part, env)
+		if isError(value) {
+			return value
+		}
+		out.WriteString(value.Inspect())
+	}
+
+	return &object.String{
+		Value: out.String(),
+	}
+}
@@ -1,6 +1,8 @@
 package evaluator
 
 import (
+	"fmt"
+	"leonardjouve/ast"
 	"leonardjouve/lexer"
 	"leonardjouve/object"
 	"leonardjouve/parser"
@@ -73,6 +75,58 @@ func TestEvalIntegerExpression(t *testing.T) {
 			input:    "(5 + 10 * 2 + 15 / 3) * 2 + -10;",
 			expected: 50,
 		},
+		{
+			input:    "0xFF;",
+			expected: 255,
+		},
+		{
+			input:    "0o17 + 0b1010;",
+			expected: 25,
+		},
+		{
+			input:    "1_000_000;",
+			expected: 1000000,
+		},
+		{
+			input:    "let item2 = 2; let item10 = 10; item2 * item10;",
+			expected: 20,
+		},
+		{
+			input:    "17 % 5;",
+			expected: 2,
+		},
+		{
+			input:    "-17 % 5;",
+			expected: -2,
+		},
+		{
+			input:    "2 + 10 % 4 * 3;",
+			expected: 8,
+		},
+		{
+			input:    "12 & 10;",
+			expected: 8,
+		},
+		{
+			input:    "12 | 10;",
+			expected: 14,
+		},
+		{
+			input:    "12 ^ 10;",
+			expected: 6,
+		},
+		{
+			input:    "1 << 4;",
+			expected: 16,
+		},
+		{
+			input:    "256 >> 2 + 2;",
+			expected: 16,
+		},
+		{
+			input:    "1 | 2 ^ 6 & 3;",
+			expected: 1,
+		},
 	}
 
 	for _, test := range tests {
@@ -81,6 +135,128 @@ func TestEvalIntegerExpression(t *testing.T) {
 	}
 }
 
+func TestEvalFloatExpression(t *testing.T) {
+	type EvalFloatExpressionTest struct {
+		input    string
+		expected float64
+	}
+	tests := []EvalFloatExpressionTest{
+		{
+			input:    "1.5;",
+			expected: 1.5,
+		},
+		{
+			input:    "-.5;",
+			expected: -0.5,
+		},
+		{
+			input:    "1.5 + 1.5;",
+			expected: 3,
+		},
+		{
+			input:    "1 + 0.5;",
+			expected: 1.5,
+		},
+		{
+			input:    "0.5 * 4;",
+			expected: 2,
+		},
+		{
+			input:    "7 / 2.0;",
+			expected: 3.5,
+		},
+		{
+			input:    "10 - 2.5 * 2;",
+			expected: 5,
+		},
+		{
+			input:    "1e3 / 8;",
+			expected: 125,
+		},
+		{
+			input:    "float(3);",
+			expected: 3,
+		},
+		{
+			input:    "float(\"2.25\");",
+			expected: 2.25,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+		testFloatObject(t, eval, test.expected)
+	}
+}
+
+func TestEvalNumberConversions(t *testing.T) {
+	type EvalNumberConversionTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []EvalNumberConversionTest{
+		{
+			input:    "int(3.9);",
+			expected: 3,
+		},
+		{
+			input:    "int(-3.9);",
+			expected: -3,
+		},
+		{
+			input:    "int(\"42\");",
+			expected: 42,
+		},
+		{
+			input:    "int(7);",
+			expected: 7,
+		},
+		{
+			input:    "int(\"abc\");",
+			expected: "invalid argument for builtin function int: \"abc\"",
+		},
+		{
+			input:    "int(true);",
+			expected: "unsupported argument for builtin function int: BOOLEAN",
+		},
+		{
+			input:    "int(1e30);",
+			expected: "invalid argument for builtin function int: 1e+30",
+		},
+		{
+			input:    "int(-1e30);",
+			expected: "invalid argument for builtin function int: -1e+30",
+		},
+		{
+			input:    "int(9223372036854775807.0);",
+			expected: "invalid argument for builtin function int: 9.223372036854776e+18",
+		},
+		{
+			input:    "int(-9223372036854775808.0);",
+			expected: -9223372036854775808,
+		},
+		{
+			input:    "float([]);",
+			expected: "unsupported argument for builtin function float: ARRAY",
+		},
+		{
+			input:    "float(1, 2);",
+			expected: "wrong arguments amount: received 2, expected 1",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case string:
+			testError(t, eval, expected)
+		}
+	}
+}
+
 func TestEvalBooleanExpression(t *testing.T) {
 	type EvalBooleanExpressionTest struct {
 		input    string
@@ -159,6 +335,22 @@ func TestEvalBooleanExpression(t *testing.T) {
 			input:    "false != true",
 			expected: true,
 		},
+		{
+			input:    "1.5 < 2",
+			expected: true,
+		},
+		{
+			input:    "2.5 > 2.5",
+			expected: false,
+		},
+		{
+			input:    "1 == 1.0",
+			expected: true,
+		},
+		{
+			input:    "0.1 + 0.2 != 0.3",
+			expected: true,
+		},
 		{
 			input:    "1 < 2 == true",
 			expected: true,
@@ -175,6 +367,90 @@ func TestEvalBooleanExpression(t *testing.T) {
 			input:    "1 > 2 == true",
 			expected: false,
 		},
+		{
+			input:    "1 <= 2",
+			expected: true,
+		},
+		{
+			input:    "2 <= 2",
+			expected: true,
+		},
+		{
+			input:    "3 <= 2",
+			expected: false,
+		},
+		{
+			input:    "1 >= 2",
+			expected: false,
+		},
+		{
+			input:    "2 >= 2",
+			expected: true,
+		},
+		{
+			input:    "2.5 >= 2",
+			expected: true,
+		},
+		{
+			input:    "1.5 <= 1",
+			expected: false,
+		},
+		{
+			input:    "\"a\" < \"b\"",
+			expected: true,
+		},
+		{
+			input:    "\"b\" <= \"a\"",
+			expected: false,
+		},
+		{
+			input:    "\"abc\" == \"abc\"",
+			expected: true,
+		},
+		{
+			input:    "\"abc\" != \"abd\"",
+			expected: true,
+		},
+		{
+			input:    "true && true",
+			expected: true,
+		},
+		{
+			input:    "true && false",
+			expected: false,
+		},
+		{
+			input:    "false || true",
+			expected: true,
+		},
+		{
+			input:    "false || false",
+			expected: false,
+		},
+		{
+			input:    "1 && \"a\"",
+			expected: true,
+		},
+		{
+			input:    "let x = 3; let n = 5; x >= 0 && x < n",
+			expected: true,
+		},
+		{
+			input:    "let x = 5; let n = 5; x >= 0 && x < n",
+			expected: false,
+		},
+		{
+			input:    "false && missing",
+			expected: false,
+		},
+		{
+			input:    "true || missing",
+			expected: true,
+		},
+		{
+			input:    "let calls = fn() { 1 / 0 }; false && calls()",
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -255,6 +531,18 @@ func TestIfElseExpressions(t *testing.T) {
 			input:    "if (1 > 2) {10} else {5}",
 			expected: 5,
 		},
+		{
+			input:    "if (1 > 2) {10} else if (2 > 1) {20} else {5}",
+			expected: 20,
+		},
+		{
+			input:    "if (1 > 2) {10} else if (2 > 3) {20} else {5}",
+			expected: 5,
+		},
+		{
+			input:    "if (1 > 2) {10} else if (2 > 3) {20}",
+			expected: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -342,139 +630,1342 @@ func TestErrorHandling(t *testing.T) {
 			expected: "unknown operation: BOOLEAN + BOOLEAN",
 		},
 		{
-			input:    "5; true + false; 5;",
-			expected: "unknown operation: BOOLEAN + BOOLEAN",
+			input:    "5; true + false; 5;",
+			expected: "unknown operation: BOOLEAN + BOOLEAN",
+		},
+		{
+			input:    "if (10 > 1) {return true + false;};",
+			expected: "unknown operation: BOOLEAN + BOOLEAN",
+		},
+		{
+			input:    "\"a\" - \"b\";",
+			expected: "unknown operation: STRING - STRING",
+		},
+		{
+			input:    "1 / 0;",
+			expected: "division by zero: 1 / 0",
+		},
+		{
+			input:    "1 % 0;",
+			expected: "division by zero: 1 % 0",
+		},
+		{
+			input:    "1 << -1;",
+			expected: "negative shift amount: 1 << -1",
+		},
+		{
+			input:    "1[0:1];",
+			expected: "unsupported slice operation: INTEGER",
+		},
+		{
+			input:    "[1][\"a\":];",
+			expected: "unsupported slice index: STRING",
+		},
+		{
+			input:    "1.5 & 1;",
+			expected: "unknown operator: FLOAT & INTEGER",
+		},
+		{
+			input:    "true && missing;",
+			expected: "identifier not found: missing",
+		},
+		{
+			input:    "if (10 > 1) {if (10 > 1) {return true + false;} return 10;};",
+			expected: "unknown operation: BOOLEAN + BOOLEAN",
+		},
+		{
+			input:    "foo;",
+			expected: "identifier not found: foo",
+		},
+		{
+			input:    "{\"name\": \"test\"}[fn(x) {return x;}];",
+			expected: "object is not hashable: FUNCTION",
+		},
+		{
+			input:    "for (x in 5) { x; }",
+			expected: "object is not iterable: INTEGER",
+		},
+		{
+			input:    "let xs = [1]; ...xs;",
+			expected: "unexpected spread expression: ...xs",
+		},
+		{
+			input:    "missing ?? 1;",
+			expected: "identifier not found: missing",
+		},
+		{
+			input:    "missing ? 1 : 2;",
+			expected: "identifier not found: missing",
+		},
+		{
+			input:    "1?[0];",
+			expected: "unsupported index operation: INTEGER",
+		},
+		{
+			input:    "5 |> 3;",
+			expected: "not a function: 3",
+		},
+		{
+			input:    "let add = fn(x, y) {x + y;}; add(1);",
+			expected: "wrong arguments amount: received 1, expected 2",
+		},
+		{
+			input:    "let add = fn(x, y) {x + y;}; add(1, 2, 3);",
+			expected: "wrong arguments amount: received 3, expected 2",
+		},
+		{
+			input:    "let add = fn(x, y = 1) {x + y;}; add();",
+			expected: "wrong arguments amount: received 0, expected 1 to 2",
+		},
+		{
+			input:    "let count = fn(first, second, ...others) {first;}; count(1);",
+			expected: "wrong arguments amount: received 1, expected at least 2",
+		},
+		{
+			input:    "let add = fn(x, y) {x + y;}; add(1, z: 2);",
+			expected: "unknown argument: z",
+		},
+		{
+			input:    "let g = fn(a) {a;}; g(b: 1);",
+			expected: "unknown argument: b",
+		},
+		{
+			input:    "let add = fn(x, y, z = 0) {x + y + z;}; add(1, z: 2);",
+			expected: "missing argument: y",
+		},
+		{
+			input:    "let add = fn(x, y = 1) {x + y;}; add(1, z: 2);",
+			expected: "unknown argument: z",
+		},
+		{
+			input:    "let add = fn(x, y) {x + y;}; add(1, x: 2);",
+			expected: "duplicate argument: x",
+		},
+		{
+			input:    "let add = fn(x, y) {x + y;}; add(x: 1, x: 2);",
+			expected: "duplicate argument: x",
+		},
+		{
+			input:    "let add = fn(x, y) {x + y;}; add(...1);",
+			expected: "cannot spread INTEGER",
+		},
+		{
+			input:    "len(x: \"abc\");",
+			expected: "unsupported named arguments for builtin function",
+		},
+		{
+			input:    "let add = fn(x, y = z) {x + y;}; add(1);",
+			expected: "identifier not found: z",
+		},
+		{
+			input:    "let [a, b] = [1, 2, 3];",
+			expected: "wrong elements amount: received 3, expected 2",
+		},
+		{
+			input:    "let [a, b, ...c] = [1];",
+			expected: "wrong elements amount: received 1, expected at least 2",
+		},
+		{
+			input:    "let [a] = 1;",
+			expected: "cannot destructure INTEGER as ARRAY",
+		},
+		{
+			input:    "let {\"a\": a} = [1];",
+			expected: "cannot destructure ARRAY as HASH",
+		},
+		{
+			input:    "let {\"a\": a} = {\"b\": 1};",
+			expected: "missing hash key: a",
+		},
+
+		{
+			input:    "const a = 1; let [a] = [2];",
+			expected: "cannot redeclare constant: a",
+		},
+		{
+			input:    "let f = fn([a, b]) { a }; f([1]);",
+			expected: "wrong elements amount: received 1, expected 2",
+		},
+		{
+			input:    "match (3) { 1 => \"one\", 2 => \"two\" }",
+			expected: "no match for value: 3",
+		},
+		{
+			input:    "match ([1, 2]) { [a] => a }",
+			expected: "no match for value: [1, 2]",
+		},
+		{
+			input:    "match (1) { n if n + true => n }",
+			expected: "type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			input:    "for (x in [1]) { x; } x;",
+			expected: "identifier not found: x",
+		},
+		{
+			input:    "for (x in [1, 2]) { x + true; }",
+			expected: "type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			input:    "throw \"boom\";",
+			expected: "boom",
+		},
+		{
+			input:    "throw error(\"bad input\");",
+			expected: "bad input",
+		},
+		{
+			input:    "try { throw 1; } catch (e) { e + true; }",
+			expected: "type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			input:    "try { 1; } finally { foo; }",
+			expected: "identifier not found: foo",
+		},
+		{
+			input:    "try { throw 1; } catch (e) { 1; } e;",
+			expected: "identifier not found: e",
+		},
+		{
+			input:    "error(1);",
+			expected: "unsupported argument for builtin function error: INTEGER",
+		},
+		{
+			input:    "error(\"a\", \"b\");",
+			expected: "unsupported argument for builtin function error: STRING",
+		},
+		{
+			input:    "const f = 1; if (true) { fn f() { 3 } }",
+			expected: "cannot redeclare constant: f",
+		},
+		{
+			input:    "const f = 1; fn f() { 2 } f;",
+			expected: "cannot redeclare constant: f",
+		},
+		{
+			input:    "fn f() { 2 } const f = 1; f;",
+			expected: "cannot redeclare constant: f",
+		},
+		{
+			input:    "let f = fn(x) { 1 + f(x) }; f(1);",
+			expected: "maximum recursion depth exceeded",
+		},
+		{
+			input:    "error();",
+			expected: "wrong arguments amount: received 0, expected 1 to 2",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+		testError(t, eval, test.expected)
+	}
+}
+
+func TestErrorPosition(t *testing.T) {
+	type ErrorPositionTest struct {
+		input    string
+		expected string
+	}
+	tests := []ErrorPositionTest{
+		{
+			input:    "foo;",
+			expected: "[Error] 1:1: identifier not found: foo",
+		},
+		{
+			input:    "let x = 5;\nlet y = x + true;",
+			expected: "[Error] 2:9: type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			input:    "let f = fn(x) {\n\treturn -x;\n};\nf(true);",
+			expected: "[Error] 2:9: unknown operation: -BOOLEAN",
+		},
+		{
+			input:    "len(1, 2)",
+			expected: "[Error] 1:1: wrong arguments amount: received 2, expected 1",
+		},
+		{
+			input:    "let x = 1;\nthrow error(\"bad input\");",
+			expected: "[Error] 2:1: bad input",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		err, ok := eval.(*object.Error)
+		if !ok {
+			t.Errorf("[Test] Invalid evaluation type: received %T, expected *object.Error", eval)
+			continue
+		}
+
+		if inspect := err.Inspect(); inspect != test.expected {
+			t.Errorf("[Test] Invalid error: received %s, expected %s", inspect, test.expected)
+		}
+	}
+}
+
+func TestErrorTraceback(t *testing.T) {
+	type ErrorTracebackTest struct {
+		input    string
+		expected string
+	}
+	tests := []ErrorTracebackTest{
+		{
+			input:    "1 + true;",
+			expected: "[Error] 1:1: type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			input:    "let inner = fn(x) {\n\tx + true;\n};\nlet outer = fn(a, b) { inner(a * b) };\nouter(2, 3);",
+			expected: "Traceback (most recent call last):\n  5:1: in outer(2, 3)\n  4:24: in inner(6)\n[Error] 2:2: type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			input:    "let f = fn(s, n = 0) { s / n };\nf(\"a very long string argument\", n: [1, 2]);",
+			expected: "Traceback (most recent call last):\n  2:1: in f(\"a very long str...\", n: [1, 2])\n[Error] 1:24: type mismatch: STRING / ARRAY",
+		},
+		{
+			input:    "let apply = fn(f, x) { f(x) };\napply(fn(x) { -true }, 1);",
+			expected: "Traceback (most recent call last):\n  2:1: in apply(fn, 1)\n  1:24: in f(1)\n[Error] 2:15: unknown operation: -BOOLEAN",
+		},
+		{
+			input:    "fn fail(x) { x + true }\nlet alias = fail;\nalias(1);",
+			expected: "Traceback (most recent call last):\n  3:1: in fail(1)\n[Error] 1:14: type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			input:    "fn loop(n) { if (n == 0) { -true } else { loop(n - 1) } }\nfn run() { loop(3) }\nrun();",
+			expected: "Traceback (most recent call last):\n  3:1: in run()\n  1:43: in loop(0)\n[Error] 1:28: unknown operation: -BOOLEAN",
+		},
+		{
+			input:    "let double = fn(x) { x * 2 };\n\"a\" |> len |> double |> len;",
+			expected: "Traceback (most recent call last):\n  2:1: in len(2)\n[Error] 2:1: unsupported argument for builtin function len: INTEGER",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		err, ok := eval.(*object.Error)
+		if !ok {
+			t.Errorf("[Test] Invalid evaluation type: received %T, expected *object.Error", eval)
+			continue
+		}
+
+		if traceback := err.Traceback(); traceback != test.expected {
+			t.Errorf("[Test] Invalid traceback: received %q, expected %q", traceback, test.expected)
+		}
+	}
+}
+
+func TestEvalLetStatements(t *testing.T) {
+	type EvalLetStatementsTests struct {
+		input    string
+		expected int64
+	}
+	tests := []EvalLetStatementsTests{
+		{
+			input:    "let x = 5; x;",
+			expected: 5,
+		},
+		{
+			input:    "let x = 5 * 5; x;",
+			expected: 25,
+		},
+		{
+			input:    "let x = 5; let y = x; y;",
+			expected: 5,
+		},
+		{
+			input:    "let x = 5; let y = x; let z = x + y + 5; z;",
+			expected: 15,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+		testIntegerObject(t, eval, test.expected)
+	}
+}
+
+func TestFunctionObject(t *testing.T) {
+	input := "fn(x) {return x + 2;}"
+	eval := testEval(input)
+
+	function, ok := eval.(*object.Function)
+	if !ok {
+		t.Fatalf("[Test] Invalid evaluation type: received %T, expected *object.Function", eval)
+	}
+
+	expectedParamsAmount := 1
+	if paramsAmount := len(function.Parameters); paramsAmount != expectedParamsAmount {
+		t.Fatalf("[Test] Invalid parameters amout: received %d, expected %d", expectedParamsAmount, paramsAmount)
+	}
+
+	expectedFunctionParameter := "x"
+	if functionParameter := function.Parameters[0].String(); functionParameter != expectedFunctionParameter {
+		t.Fatalf("[Test] Invalid parameter: received %s, expected %s", functionParameter, expectedFunctionParameter)
+	}
+
+	expectedFunctionBody := "return (x + 2)"
+	if functionBody := function.Body.String(); functionBody != expectedFunctionBody {
+		t.Fatalf("[Test] Invalid function body: received %s, expected %s", functionBody, expectedFunctionBody)
+	}
+}
+
+func TestNamedFunctions(t *testing.T) {
+	type NamedFunctionTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []NamedFunctionTest{
+		{
+			input:    "fn add(x, y) { x + y } add(2, 3);",
+			expected: 5,
+		},
+		{
+			input:    "let r = square(4); fn square(x) { x * x } r;",
+			expected: 16,
+		},
+		{
+			input:    "fn isEven(n) { if (n == 0) { true } else { isOdd(n - 1) } } fn isOdd(n) { if (n == 0) { false } else { isEven(n - 1) } } isOdd(7);",
+			expected: true,
+		},
+		{
+			input:    "let fact = fn f(n) { if (n < 2) { 1 } else { n * f(n - 1) } }; fact(5);",
+			expected: 120,
+		},
+		{
+			input:    "let f = 1; let g = fn f(n) { if (n == 0) { 0 } else { f(n - 1) } }; g(3) + f;",
+			expected: 1,
+		},
+		{
+			input:    "let outer = fn() { fn inner() { 7 } inner() }; outer();",
+			expected: 7,
+		},
+		{
+			input:    "fn f() { 1 } let g = f; fn f() { 2 } g();",
+			expected: 2,
+		},
+		{
+			input:    "let f = fn() { fn inner() { 1 } }; f();",
+			expected: nil,
+		},
+		{
+			input:    "let x = if (true) { fn g() { 1 } }; x;",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case bool:
+			testBooleanObject(t, eval, expected)
+		case nil:
+			testNullObject(t, eval)
+		}
+	}
+}
+
+func TestTailCalls(t *testing.T) {
+	type TailCallTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []TailCallTest{
+		{
+			input:    "fn count(n, acc) { if (n == 0) { return acc; } count(n - 1, acc + 1) } count(100000, 0);",
+			expected: 100000,
+		},
+		{
+			input:    "let count = fn(n, acc) { if (n == 0) { acc } else { count(n - 1, acc + 1) } }; count(1000001, 0);",
+			expected: 1000001,
+		},
+		{
+			input:    "fn isEven(n) { if (n == 0) { true } else { isOdd(n - 1) } } fn isOdd(n) { n == 0 ? false : isEven(n - 1) } isEven(100001);",
+			expected: false,
+		},
+		{
+			input:    "let loop = fn(n) { match (n) { 0 => \"done\", _ => loop(n - 1) } }; loop(100000);",
+			expected: "done",
+		},
+		{
+			input:    "let loop = fn(n) { while (true) { if (n == 0) { return 0; } return loop(n - 1); } }; loop(100000);",
+			expected: 0,
+		},
+		{
+			input:    "let sum = (n, acc = 0) => n == 0 ? acc : sum(n - 1, acc: acc + n); sum(100000);",
+			expected: 5000050000,
+		},
+		{
+			input:    "fn fact(n) { if (n < 2) { 1 } else { n * fact(n - 1) } } fact(10);",
+			expected: 3628800,
+		},
+		{
+			input:    "fn g() { throw \"boom\"; } fn f() { try { return g(); } catch (e) { \"caught \" + e } } f();",
+			expected: "caught boom",
+		},
+		{
+			input:    "let log = \"\"; fn g() { log += \"g\"; 1 } fn f() { try { return g(); } finally { log += \"f\"; } } f(); log;",
+			expected: "gf",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case bool:
+			testBooleanObject(t, eval, expected)
+		case string:
+			testStringObject(t, eval, expected)
+		}
+	}
+}
+
+func TestMaxCallDepth(t *testing.T) {
+	maxCallDepth := MaxCallDepth
+	MaxCallDepth = 30
+	defer func() {
+		MaxCallDepth = maxCallDepth
+	}()
+
+	expectedTraceback := "Traceback (most recent call last):\n  ... 11 more frames\n"
+	for n := 19; n >= 0; n-- {
+		expectedTraceback += fmt.Sprintf("  1:44: in depth(%d)\n", n)
+	}
+	expectedTraceback += "[Error] 1:44: maximum recursion depth exceeded"
+
+	type MaxCallDepthTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []MaxCallDepthTest{
+		{
+			input:    "fn depth(n) { if (n == 0) { 0 } else { 1 + depth(n - 1) } } depth(29);",
+			expected: 29,
+		},
+		{
+			input:    "fn depth(n) { if (n == 0) { 0 } else { 1 + depth(n - 1) } } depth(30);",
+			expected: expectedTraceback,
+		},
+		{
+			input:    "fn count(n) { if (n == 0) { 0 } else { count(n - 1) } } count(1000);",
+			expected: 0,
+		},
+		{
+			input:    "let f = fn(x) { 1 + f(x) }; let out = \"\"; try { f(1); } catch (e) { out = e[\"message\"]; } out;",
+			expected: "maximum recursion depth exceeded",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		par := parser.New(lex)
+		program := par.ParseProgram()
+		env := object.NewEnvironement()
+		eval := Eval(program, env)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case string:
+			if err, ok := eval.(*object.Error); ok {
+				if traceback := err.Traceback(); traceback != expected {
+					t.Errorf("[Test] Invalid traceback: received %q, expected %q", traceback, expected)
+				}
+				continue
+			}
+			testStringObject(t, eval, expected)
+		}
+
+		if depth := env.CallDepth(); depth != 0 {
+			t.Errorf("[Test] Invalid call depth after evaluation: received %d, expected 0", depth)
+		}
+	}
+}
+
+func TestNamedFunctionObject(t *testing.T) {
+	input := "let g = fn f(x) { x }; g;"
+	eval := testEval(input)
+
+	function, ok := eval.(*object.Function)
+	if !ok {
+		t.Fatalf("[Test] Invalid evaluation type: received %T, expected *object.Function", eval)
+	}
+
+	expectedName := "f"
+	if name := string(function.Name); name != expectedName {
+		t.Fatalf("[Test] Invalid function name: received %s, expected %s", name, expectedName)
+	}
+
+	expectedInspect := "fn f(x) {\nx\n}"
+	if inspect := function.Inspect(); inspect != expectedInspect {
+		t.Fatalf("[Test] Invalid function inspect: received %s, expected %s", inspect, expectedInspect)
+	}
+}
+
+func TestFunctionApplication(t *testing.T) {
+	type FunctionApplicationTest struct {
+		input    string
+		expected int64
+	}
+	tests := []FunctionApplicationTest{
+		{
+			input:    "let identify = fn(x) {x;}; identify(5);",
+			expected: 5,
+		},
+		{
+			input:    "let identify = fn(x) {return x;}; identify(5);",
+			expected: 5,
+		},
+		{
+			input:    "let double = fn(x) {x * 2;}; double(5);",
+			expected: 10,
+		},
+		{
+			input:    "let double = fn(x) {return x * 2;}; double(5);",
+			expected: 10,
+		},
+		{
+			input:    "let add = fn(x, y) {x + y;}; add(5, 5);",
+			expected: 10,
+		},
+		{
+			input:    "let add = fn(x, y) {x + y;}; add(5 + 5, add(5, 5));",
+			expected: 20,
+		},
+		{
+			input:    "fn(x) {x;}(5);",
+			expected: 5,
+		},
+		{
+			input:    "let add = fn(x, y = 10) {x + y;}; add(5);",
+			expected: 15,
+		},
+		{
+			input:    "let add = fn(x, y = 10) {x + y;}; add(5, 1);",
+			expected: 6,
+		},
+		{
+			input:    "let add = fn(x, y = x * 2) {x + y;}; add(5);",
+			expected: 15,
+		},
+		{
+			input:    "let count = fn(first, ...others) {first + len(others);}; count(10, 1, 2, 3);",
+			expected: 13,
+		},
+		{
+			input:    "let count = fn(first, ...others) {first + len(others);}; count(10);",
+			expected: 10,
+		},
+		{
+			input:    "let add = fn(x, y, z) {x + y + z;}; let args = [1, 2, 3]; add(...args);",
+			expected: 6,
+		},
+		{
+			input:    "let add = fn(x, y, z) {x + y + z;}; add(1, ...[2, 3]);",
+			expected: 6,
+		},
+		{
+			input:    "let sub = fn(x, y) {x - y;}; sub(y: 2, x: 10);",
+			expected: 8,
+		},
+		{
+			input:    "let f = fn(x, y = 2, z = 3) {x * 100 + y * 10 + z;}; f(1, z: 5);",
+			expected: 125,
+		},
+		{
+			input:    "len(...[[1, 2, 3]]);",
+			expected: 3,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+		testIntegerObject(t, eval, test.expected)
+	}
+}
+
+func TestArrowFunctionsAndPipelines(t *testing.T) {
+	type ArrowFunctionTest struct {
+		input    string
+		expected int64
+	}
+	tests := []ArrowFunctionTest{
+		{
+			input:    "let double = x => x * 2; double(5);",
+			expected: 10,
+		},
+		{
+			input:    "let add = (x, y) => x + y; add(2, 3);",
+			expected: 5,
+		},
+		{
+			input:    "let seven = () => 7; seven();",
+			expected: 7,
+		},
+		{
+			input:    "let adder = x => y => x + y; adder(2)(3);",
+			expected: 5,
+		},
+		{
+			input:    "let f = (x, y = 10) => { let z = x + y; z * 2 }; f(1);",
+			expected: 22,
+		},
+		{
+			input:    "let double = x => x * 2; 5 |> double;",
+			expected: 10,
+		},
+		{
+			input:    "let sub = (x, y) => x - y; 10 |> sub(3);",
+			expected: 7,
+		},
+		{
+			input:    "let sub = (x, y) => x - y; let double = x => x * 2; 10 |> sub(4) |> double;",
+			expected: 12,
+		},
+		{
+			input:    "[1, 2, 3] |> len;",
+			expected: 3,
+		},
+		{
+			input:    "let map = fn(arr, f) { let out = []; for (x in arr) { out = push(out, f(x)); } out }; let sum = fn(arr) { let total = 0; for (x in arr) { total += x; } total }; [1, 2, 3] |> map(x => x * 10) |> sum;",
+			expected: 60,
+		},
+		{
+			input:    "5 |> (x => x + 1);",
+			expected: 6,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+		testIntegerObject(t, eval, test.expected)
+	}
+}
+
+func TestConditionalExpressions(t *testing.T) {
+	type ConditionalExpressionTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []ConditionalExpressionTest{
+		{
+			input:    "1 < 2 ? 10 : 20",
+			expected: 10,
+		},
+		{
+			input:    "1 > 2 ? 10 : 20",
+			expected: 20,
+		},
+		{
+			input:    "let sign = fn(x) { x < 0 ? -1 : x == 0 ? 0 : 1 }; sign(-5) * 100 + sign(0) * 10 + sign(5);",
+			expected: -99,
+		},
+		{
+			input:    "true ? 1 : missing",
+			expected: 1,
+		},
+		{
+			input:    "false ? missing : 2",
+			expected: 2,
+		},
+		{
+			input:    "let h = {\"a\": 1}; h[\"b\"] ?? 5;",
+			expected: 5,
+		},
+		{
+			input:    "let h = {\"a\": 1}; h[\"a\"] ?? 5;",
+			expected: 1,
+		},
+		{
+			input:    "0 ?? 5",
+			expected: 0,
+		},
+		{
+			input:    "1 ?? missing",
+			expected: 1,
+		},
+		{
+			input:    "let h = {\"a\": {\"b\": 2}}; h[\"a\"]?[\"b\"];",
+			expected: 2,
+		},
+		{
+			input:    "let h = {\"a\": {\"b\": 2}}; h[\"x\"]?[\"b\"] ?? 7;",
+			expected: 7,
+		},
+		{
+			input:    "let h = {}; h[\"x\"]?[missing];",
+			expected: nil,
+		},
+		{
+			input:    "let h = {}; h[\"x\"]?[1:];",
+			expected: nil,
+		},
+		{
+			input:    "let arr = [1, 2, 3]; len(arr?[1:]);",
+			expected: 2,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case nil:
+			testNullObject(t, eval)
+		}
+	}
+}
+
+func TestClosures(t *testing.T) {
+	input := "let newAdder = fn(x) {return fn(y) {return x + y;};}; let x = 10; let y = 10; let addTwo = newAdder(2); addTwo(2);"
+
+	eval := testEval(input)
+	testIntegerObject(t, eval, 4)
+}
+
+func TestAssignExpressions(t *testing.T) {
+	type AssignExpressionTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []AssignExpressionTest{
+		{
+			input:    "let x = 1; x = 2; x;",
+			expected: 2,
+		},
+		{
+			input:    "let x = 1; x = 5;",
+			expected: 5,
+		},
+		{
+			input:    "let x = 1; let y = 1; x = y = 3; x + y;",
+			expected: 6,
+		},
+		{
+			input:    "let x = 10; x += 5; x -= 3; x *= 2; x /= 4; x;",
+			expected: 6,
+		},
+		{
+			input:    "let counter = fn() { let count = 0; fn() { count += 1; count } }; let next = counter(); next(); next(); next();",
+			expected: 3,
+		},
+		{
+			input:    "let total = 0; let add = fn(x) { total = total + x; }; add(2); add(3); total;",
+			expected: 5,
+		},
+		{
+			input:    "let x = 1; let shadow = fn() { let x = 2; x = 3; x }; shadow() * 10 + x;",
+			expected: 31,
+		},
+		{
+			input:    "let a = [1, 2, 3]; a[1] = 20; a[1] + a[2];",
+			expected: 23,
+		},
+		{
+			input:    "let a = [1, 2, 3]; let b = a; b[0] += 9; a[0];",
+			expected: 10,
+		},
+		{
+			input:    "let h = {\"a\": 1}; h[\"a\"] *= 7; h[\"b\"] = 2; h[\"a\"] + h[\"b\"];",
+			expected: 9,
+		},
+		{
+			input:    "y = 1;",
+			expected: "identifier not found: y",
+		},
+		{
+			input:    "let a = [1]; a[1] = 2;",
+			expected: "index out of range: 1",
+		},
+		{
+			input:    "let h = {}; h[fn(x) { x }] = 1;",
+			expected: "object is not hashable: FUNCTION",
+		},
+		{
+			input:    "let x = 1; x += true;",
+			expected: "type mismatch: INTEGER + BOOLEAN",
+		},
+		{
+			input:    "let s = \"abc\"; s[0] = \"z\";",
+			expected: "unsupported index assignment: STRING",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case string:
+			testError(t, eval, expected)
+		}
+	}
+}
+
+func TestConstStatements(t *testing.T) {
+	type ConstStatementTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []ConstStatementTest{
+		{
+			input:    "const x = 5; x;",
+			expected: 5,
+		},
+		{
+			input:    "const x = 5; let f = fn() { let x = 10; x }; f() + x;",
+			expected: 15,
+		},
+		{
+			input:    "const x = 5; let f = fn() { const x = 1; x }; f() + x;",
+			expected: 6,
+		},
+		{
+			input:    "const config = [1, 2]; config[0] = 3; config[0];",
+			expected: 3,
+		},
+		{
+			input:    "const x = 5; x = 6;",
+			expected: "cannot assign to constant: x",
+		},
+		{
+			input:    "const x = 5; x += 1;",
+			expected: "cannot assign to constant: x",
+		},
+		{
+			input:    "const x = 5; let f = fn() { x = 6; }; f();",
+			expected: "cannot assign to constant: x",
+		},
+		{
+			input:    "const x = 5; let x = 6;",
+			expected: "cannot redeclare constant: x",
+		},
+		{
+			input:    "const x = 5; const x = 6;",
+			expected: "cannot redeclare constant: x",
+		},
+		{
+			input:    "let x = 5; const x = 6; x;",
+			expected: 6,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case string:
+			testError(t, eval, expected)
+		}
+	}
+}
+
+func TestDestructuring(t *testing.T) {
+	type DestructuringTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []DestructuringTest{
+		{
+			input:    "let [a, b] = [1, 2]; a * 10 + b;",
+			expected: 12,
+		},
+		{
+			input:    "let [first, ...rest] = [1, 2, 3]; first + len(rest);",
+			expected: 3,
+		},
+		{
+			input:    "let [first, ...rest] = [1]; len(rest);",
+			expected: 0,
+		},
+		{
+			input:    "let [_, second] = [1, 2]; second;",
+			expected: 2,
+		},
+		{
+			input:    "let {\"name\": n, \"age\": a} = {\"name\": \"ada\", \"age\": 36}; a;",
+			expected: 36,
+		},
+		{
+			input:    "let {\"name\": n} = {\"name\": \"ada\", \"age\": 36}; n;",
+			expected: "ada",
+		},
+		{
+			input:    "let [{\"x\": x}, [y, z]] = [{\"x\": 1}, [2, 3]]; x + y + z;",
+			expected: 6,
+		},
+		{
+			input:    "let sum = fn([a, b]) { a + b }; sum([3, 4]);",
+			expected: 7,
+		},
+		{
+			input:    "let name = fn({\"name\": n}, greeting) { greeting + \" \" + n }; name({\"name\": \"ada\"}, \"hi\");",
+			expected: "hi ada",
+		},
+		{
+			input:    "let tail = fn([_, ...rest]) { rest }; len(tail([1, 2, 3, 4]));",
+			expected: 3,
+		},
+		{
+			input:    "match ([1, 2, 3]) { [1, ...rest] => len(rest), _ => 0 }",
+			expected: 2,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case string:
+			testStringObject(t, eval, expected)
+		}
+	}
+}
+
+func TestDestructuringKeyError(t *testing.T) {
+	pattern := &ast.HashLiteral{
+		Value: map[ast.Expression]ast.Expression{
+			&ast.Identifier{Value: "missing"}: &ast.Identifier{Value: "a"},
+		},
+	}
+	hash := &object.Hash{
+		Value: map[object.HashKey]object.HashPair{},
+	}
+
+	err := bindPattern(pattern, hash, object.NewEnvironement())
+	if err == nil {
+		t.Fatalf("[Test] Invalid evaluation: received nil, expected *object.Error")
+	}
+
+	if expected := "identifier not found: missing"; err.Value != expected {
+		t.Errorf("[Test] Invalid error message: received %s, expected %s", err.Value, expected)
+	}
+}
+
+func TestMatchExpressions(t *testing.T) {
+	type MatchExpressionTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []MatchExpressionTest{
+		{
+			input:    "match (2) { 1 => \"one\", 2 => \"two\", _ => \"many\" }",
+			expected: "two",
+		},
+		{
+			input:    "match (7) { 1 => \"one\", 2 => \"two\", _ => \"many\" }",
+			expected: "many",
+		},
+		{
+			input:    "match (-1) { -1 => \"negative\", _ => \"other\" }",
+			expected: "negative",
+		},
+		{
+			input:    "match (2.0) { 2 => \"two\", _ => \"other\" }",
+			expected: "two",
+		},
+		{
+			input:    "match (\"hi\") { 1 => \"int\", \"hi\" => \"string\", _ => \"other\" }",
+			expected: "string",
+		},
+		{
+			input:    "match (true) { false => 0, true => 1 }",
+			expected: 1,
+		},
+		{
+			input:    "match ([1, 2]) { [a] => a, [a, b] => a + b, _ => 0 }",
+			expected: 3,
+		},
+		{
+			input:    "match ([1, [2, 3]]) { [1, [_, c]] => c, _ => 0 }",
+			expected: 3,
+		},
+		{
+			input:    "match ({\"name\": \"ada\", \"age\": 36}) { {\"name\": n, \"age\": 36} => n, _ => \"?\" }",
+			expected: "ada",
+		},
+		{
+			input:    "match ({\"name\": \"ada\"}) { {\"age\": a} => a, _ => \"missing\" }",
+			expected: "missing",
+		},
+		{
+			input:    "match (5) { n if n > 10 => \"big\", n if n > 1 => \"medium\", _ => \"small\" }",
+			expected: "medium",
+		},
+		{
+			input:    "match ([3, 1]) { [a, b] if a < b => \"ascending\", [a, b] => \"descending\" }",
+			expected: "descending",
+		},
+		{
+			input:    "let n = 1; match (5) { n => n }; n;",
+			expected: 1,
+		},
+		{
+			input:    "match (4) { n => { let doubled = n * 2; doubled + 1 } }",
+			expected: 9,
+		},
+		{
+			input:    "let f = fn(x) { match (x) { 0 => { return \"zero\"; } _ => \"nonzero\" }; \"after\" }; f(0);",
+			expected: "zero",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case string:
+			testStringObject(t, eval, expected)
+		}
+	}
+}
+
+func TestLoops(t *testing.T) {
+	type LoopTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []LoopTest{
+		{
+			input:    "let i = 0; while (i < 10) { i += 1; } i;",
+			expected: 10,
+		},
+		{
+			input:    "let i = 0; while (i < 3) { i += 1 }; let total = 0; for (let j = 0; j < i; j += 1) { total += j; }; total;",
+			expected: 3,
+		},
+		{
+			input:    "let total = 0; for (let i = 0; i < 5; i += 1) { total += i; } total;",
+			expected: 10,
+		},
+		{
+			input:    "let total = 0; for (let i = 0; i < 10; i += 1) { if (i % 2 == 0) { continue; } total += i; } total;",
+			expected: 25,
+		},
+		{
+			input:    "let i = 0; while (true) { if (i == 3) { break; } i += 1; } i;",
+			expected: 3,
+		},
+		{
+			input:    "let count = 0; for (let i = 0; i < 3; i += 1) { for (let j = 0; j < 3; j += 1) { if (j == 1) { break; } count += 1; } } count;",
+			expected: 3,
+		},
+		{
+			input:    "let find = fn(arr, x) { for (let i = 0; i < len(arr); i += 1) { if (arr[i] == x) { return i; } } -1 }; find([4, 5, 6], 6);",
+			expected: 2,
+		},
+		{
+			input:    "let i = 0; for (;;) { i += 1; if (i > 100000) { break; } } i;",
+			expected: 100001,
+		},
+		{
+			input:    "let i = 0; while (i < 3) { let doubled = i * 2; i += 1; } i;",
+			expected: 3,
+		},
+		{
+			input:    "while (false) { 1; }",
+			expected: nil,
+		},
+		{
+			input:    "for (let i = 0; i < 1; i += 1) { i; } i;",
+			expected: "identifier not found: i",
+		},
+		{
+			input:    "while (missing) { 1; }",
+			expected: "identifier not found: missing",
+		},
+		{
+			input:    "let i = 0; while (i < 3) { i += true; }",
+			expected: "type mismatch: INTEGER + BOOLEAN",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case string:
+			testError(t, eval, expected)
+		case nil:
+			testNullObject(t, eval)
+		}
+	}
+}
+
+func TestForInLoops(t *testing.T) {
+	type ForInLoopTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []ForInLoopTest{
+		{
+			input:    "let total = 0; for (x in [1, 2, 3]) { total += x; } total;",
+			expected: 6,
+		},
+		{
+			input:    "let total = 0; for (i, x in [10, 20, 30]) { total += i * x; } total;",
+			expected: 80,
+		},
+		{
+			input:    "let out = \"\"; for (k in {\"b\": 2, \"a\": 1, \"c\": 3}) { out += k; } out;",
+			expected: "abc",
+		},
+		{
+			input:    "let out = \"\"; for (k, v in {\"b\": 2, \"a\": 1}) { out += \"${k}=${v};\"; } out;",
+			expected: "a=1;b=2;",
+		},
+		{
+			input:    "let out = \"\"; for (ch in \"héllo\") { out = ch + out; } out;",
+			expected: "olléh",
+		},
+		{
+			input:    "let total = 0; for (i in range(5)) { total += i; } total;",
+			expected: 10,
+		},
+		{
+			input:    "let total = 0; for (i in range(10, 0, -3)) { total += i; } total;",
+			expected: 22,
+		},
+		{
+			input:    "let count = 0; for (i in range(1000000)) { if (i == 100000) { break; } count += 1; } count;",
+			expected: 100000,
+		},
+		{
+			input:    "let total = 0; for (i in range(10)) { if (i % 2 == 1) { continue; } total += i; } total;",
+			expected: 20,
+		},
+		{
+			input:    "let find = fn(arr, x) { for (i, y in arr) { if (y == x) { return i; } } -1 }; find([4, 5, 6], 5);",
+			expected: 1,
+		},
+		{
+			input:    "for (x in []) { x; }",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case string:
+			testStringObject(t, eval, expected)
+		case nil:
+			testNullObject(t, eval)
+		}
+	}
+}
+
+func TestExceptions(t *testing.T) {
+	type ExceptionTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []ExceptionTest{
+		{
+			input:    "let r = \"\"; try { throw \"boom\"; } catch (e) { r = e; } r;",
+			expected: "boom",
 		},
 		{
-			input:    "if (10 > 1) {return true + false;};",
-			expected: "unknown operation: BOOLEAN + BOOLEAN",
+			input:    "let f = fn() { try { throw 1; } catch (e) { return e + 1; } }; f();",
+			expected: 2,
 		},
 		{
-			input:    "\"a\" - \"b\";",
-			expected: "unknown operation: STRING - STRING",
+			input:    "let h = {}; let out = \"\"; try { h[\"a\"] + 1; } catch (e) { out = e[\"kind\"] + \": \" + e[\"message\"]; } out;",
+			expected: "RuntimeError: type mismatch: NULL + INTEGER",
 		},
 		{
-			input:    "if (10 > 1) {if (10 > 1) {return true + false;} return 10;};",
-			expected: "unknown operation: BOOLEAN + BOOLEAN",
+			input:    "let out = \"\"; try {\n  len(1);\n} catch (e) { out = e[\"location\"]; } out;",
+			expected: "2:3",
 		},
 		{
-			input:    "foo;",
-			expected: "identifier not found: foo",
+			input:    "let out = \"\"; try { throw error(\"bad input\"); } catch (e) { out = e[\"kind\"] + \": \" + e[\"message\"]; } out;",
+			expected: "Error: bad input",
 		},
 		{
-			input:    "{\"name\": \"test\"}[fn(x) {return x;}];",
-			expected: "object is not hashable: FUNCTION",
+			input:    "let out = \"\"; try { try { foo; } catch (e) { throw error(\"wrapped\", e); } } catch (e) { out = e[\"cause\"][\"message\"]; } out;",
+			expected: "identifier not found: foo",
 		},
-	}
-
-	for _, test := range tests {
-		eval := testEval(test.input)
-		testError(t, eval, test.expected)
-	}
-}
-
-func TestEvalLetStatements(t *testing.T) {
-	type EvalLetStatementsTests struct {
-		input    string
-		expected int64
-	}
-	tests := []EvalLetStatementsTests{
 		{
-			input:    "let x = 5; x;",
-			expected: 5,
+			input:    "let out = 0; try { throw error(\"x\"); } catch (e) { out = e[\"cause\"]; } out;",
+			expected: nil,
 		},
 		{
-			input:    "let x = 5 * 5; x;",
-			expected: 25,
+			input:    "let log = \"\"; try { log += \"a\"; } finally { log += \"b\"; } log;",
+			expected: "ab",
 		},
 		{
-			input:    "let x = 5; let y = x; y;",
-			expected: 5,
+			input:    "let log = \"\"; try { throw 1; } catch { log += \"c\"; } finally { log += \"f\"; } log;",
+			expected: "cf",
 		},
 		{
-			input:    "let x = 5; let y = x; let z = x + y + 5; z;",
-			expected: 15,
+			input:    "let log = \"\"; let f = fn() { try { return 1; } finally { log += \"f\"; } }; f(); log;",
+			expected: "f",
 		},
-	}
-
-	for _, test := range tests {
-		eval := testEval(test.input)
-		testIntegerObject(t, eval, test.expected)
-	}
-}
-
-func TestFunctionObject(t *testing.T) {
-	input := "fn(x) {return x + 2;}"
-	eval := testEval(input)
-
-	function, ok := eval.(*object.Function)
-	if !ok {
-		t.Fatalf("[Test] Invalid evaluation type: received %T, expected *object.Function", eval)
-	}
-
-	expectedParamsAmount := 1
-	if paramsAmount := len(function.Parameters); paramsAmount != expectedParamsAmount {
-		t.Fatalf("[Test] Invalid parameters amout: received %d, expected %d", expectedParamsAmount, paramsAmount)
-	}
-
-	expectedFunctionParameter := "x"
-	if functionParameter := function.Parameters[0].String(); functionParameter != expectedFunctionParameter {
-		t.Fatalf("[Test] Invalid parameter: received %s, expected %s", functionParameter, expectedFunctionParameter)
-	}
-
-	expectedFunctionBody := "return (x + 2)"
-	if functionBody := function.Body.String(); functionBody != expectedFunctionBody {
-		t.Fatalf("[Test] Invalid function body: received %s, expected %s", functionBody, expectedFunctionBody)
-	}
-}
-
-func TestFunctionApplication(t *testing.T) {
-	type FunctionApplicationTest struct {
-		input    string
-		expected int64
-	}
-	tests := []FunctionApplicationTest{
 		{
-			input:    "let identify = fn(x) {x;}; identify(5);",
-			expected: 5,
+			input:    "let f = fn() { try { return 1; } finally { return 2; } }; f();",
+			expected: 2,
 		},
 		{
-			input:    "let identify = fn(x) {return x;}; identify(5);",
-			expected: 5,
+			input:    "let total = 0; for (i in range(5)) { try { if (i == 3) { break; } total += i; } finally { total += 10; } } total;",
+			expected: 43,
 		},
 		{
-			input:    "let double = fn(x) {x * 2;}; double(5);",
-			expected: 10,
+			input:    "let out = 0; try { try { throw 1; } finally { out += 1; } } catch (e) { out += e * 10; } out;",
+			expected: 11,
 		},
 		{
-			input:    "let double = fn(x) {return x * 2;}; double(5);",
-			expected: 10,
+			input:    "let e = error(\"again\"); fn f() { throw e; } let out = []; for (i in range(3)) { try { f(); } catch (c) { out = push(out, c[\"traceback\"]); } } out[0] == out[2] && len(out[1]) == len(out[2]);",
+			expected: true,
 		},
 		{
-			input:    "let add = fn(x, y) {x + y;}; add(5, 5);",
-			expected: 10,
+			input:    "let e = error(\"again\"); let out = \"\";\ntry { throw e; } catch (c) { out = c[\"location\"]; }\ntry { throw e; } catch (c) { out = out + \",\" + c[\"location\"]; } out;",
+			expected: "2:7,3:7",
 		},
 		{
-			input:    "let add = fn(x, y) {x + y;}; add(5 + 5, add(5, 5));",
-			expected: 20,
+			input:    "let e = error(\"not thrown\"); e[\"message\"];",
+			expected: "not thrown",
 		},
 		{
-			input:    "fn(x) {x;}(5);",
-			expected: 5,
+			input:    "let out = \"\"; let f = fn() { foo };\ntry { f(); } catch (e) { out = e[\"traceback\"]; } out;",
+			expected: "Traceback (most recent call last):\n  2:7: in f()\n[Error] 1:30: identifier not found: foo",
 		},
 	}
 
 	for _, test := range tests {
 		eval := testEval(test.input)
-		testIntegerObject(t, eval, test.expected)
-	}
-}
-
-func TestClosures(t *testing.T) {
-	input := "let newAdder = fn(x) {return fn(y) {return x + y;};}; let x = 10; let y = 10; let addTwo = newAdder(2); addTwo(2);"
 
-	eval := testEval(input)
-	testIntegerObject(t, eval, 4)
+		switch expected := test.expected.(type) {
+		case int:
+			testIntegerObject(t, eval, int64(expected))
+		case bool:
+			testBooleanObject(t, eval, expected)
+		case string:
+			testStringObject(t, eval, expected)
+		case nil:
+			testNullObject(t, eval)
+		}
+	}
 }
 
 func TestStringLiterals(t *testing.T) {
@@ -505,6 +1996,51 @@ func TestStringConcatenation(t *testing.T) {
 	}
 }
 
+func TestStringInterpolation(t *testing.T) {
+	type StringInterpolationTest struct {
+		input    string
+		expected string
+	}
+	tests := []StringInterpolationTest{
+		{
+			input:    "let name = \"Bob\"; let items = [1, 2]; \"Hello ${name}, you have ${len(items)} items\"",
+			expected: "Hello Bob, you have 2 items",
+		},
+		{
+			input:    "\"${1.5} ${true} ${[1, \"a\"]} ${\"in${\"ne\"}r\"}\"",
+			expected: "1.5 true [1, a] inner",
+		},
+		{
+			input:    "\"\\${x}\"",
+			expected: "${x}",
+		},
+		{
+			input:    "\"value: ${x}\"",
+			expected: "identifier not found: x",
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		if errorObject, ok := eval.(*object.Error); ok {
+			if errorObject.Value != test.expected {
+				t.Errorf("[Test] Invalid error message: received %s, expected %s", errorObject.Value, test.expected)
+			}
+			continue
+		}
+
+		str, ok := eval.(*object.String)
+		if !ok {
+			t.Fatalf("[Test] Invalid object type: received %T, expected *object.String", eval)
+		}
+
+		if str.Value != test.expected {
+			t.Errorf("[Test] Invalid string object value: received %s, expected %s", str.Value, test.expected)
+		}
+	}
+}
+
 func TestBuiltinFunctions(t *testing.T) {
 	type BuiltinFunctionTest struct {
 		input    string
@@ -531,6 +2067,14 @@ func TestBuiltinFunctions(t *testing.T) {
 			input:    "len(\"one\", \"two\")",
 			expected: "wrong arguments amount: received 2, expected 1",
 		},
+		{
+			input:    "len(\"é\")",
+			expected: 1,
+		},
+		{
+			input:    "len(\"café 😀\")",
+			expected: 6,
+		},
 		{
 			input:    "len([1, 2, 3])",
 			expected: 3,
@@ -539,6 +2083,34 @@ func TestBuiltinFunctions(t *testing.T) {
 			input:    "len([])",
 			expected: 0,
 		},
+		{
+			input:    "len(range(10))",
+			expected: 10,
+		},
+		{
+			input:    "len(range(1, 10, 3))",
+			expected: 3,
+		},
+		{
+			input:    "len(range(5, 0, -2))",
+			expected: 3,
+		},
+		{
+			input:    "len(range(5, 0))",
+			expected: 0,
+		},
+		{
+			input:    "range(0, 10, 0)",
+			expected: "invalid argument for builtin function range: step cannot be 0",
+		},
+		{
+			input:    "range(\"10\")",
+			expected: "unsupported argument for builtin function range: STRING",
+		},
+		{
+			input:    "range()",
+			expected: "wrong arguments amount: received 0, expected 1 to 3",
+		},
 		{
 			input:    "first([1, 2, 3])",
 			expected: 1,
@@ -693,6 +2265,118 @@ func TestArrayIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestStringIndexExpressions(t *testing.T) {
+	type StringIndexExpressionTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []StringIndexExpressionTest{
+		{
+			input:    "\"café\"[0]",
+			expected: "c",
+		},
+		{
+			input:    "\"café\"[3]",
+			expected: "é",
+		},
+		{
+			input:    "let s = \"😀!\"; s[len(s) - 1]",
+			expected: "!",
+		},
+		{
+			input:    "\"café\"[4]",
+			expected: nil,
+		},
+		{
+			input:    "\"café\"[-1]",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+		expected, ok := test.expected.(string)
+		if !ok {
+			testNullObject(t, eval)
+			continue
+		}
+		testStringObject(t, eval, expected)
+	}
+}
+
+func TestSliceExpressions(t *testing.T) {
+	type SliceExpressionTest struct {
+		input    string
+		expected interface{}
+	}
+	tests := []SliceExpressionTest{
+		{
+			input:    "\"héllo wörld\"[0:5]",
+			expected: "héllo",
+		},
+		{
+			input:    "\"héllo wörld\"[6:]",
+			expected: "wörld",
+		},
+		{
+			input:    "\"héllo\"[:2]",
+			expected: "hé",
+		},
+		{
+			input:    "\"héllo\"[:]",
+			expected: "héllo",
+		},
+		{
+			input:    "\"héllo\"[3:1]",
+			expected: "",
+		},
+		{
+			input:    "\"héllo\"[-5:100]",
+			expected: "héllo",
+		},
+		{
+			input:    "[1, 2, 3, 4][1:3]",
+			expected: []int{2, 3},
+		},
+		{
+			input:    "[1, 2, 3, 4][:1 + 1]",
+			expected: []int{1, 2},
+		},
+		{
+			input:    "let a = [1, 2, 3]; a[1:]",
+			expected: []int{2, 3},
+		},
+		{
+			input:    "[1, 2, 3][2:2]",
+			expected: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		eval := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case string:
+			testStringObject(t, eval, expected)
+		case []int:
+			array, ok := eval.(*object.Array)
+			if !ok {
+				t.Errorf("[Test] Invalid object type: received %T, expected *object.Array", eval)
+				continue
+			}
+
+			if elementAmount := len(array.Value); elementAmount != len(expected) {
+				t.Errorf("[Test] Invalid array element amount: received %d, expected %d", elementAmount, len(expected))
+				continue
+			}
+
+			for i, expectedElement := range expected {
+				testIntegerObject(t, array.Value[i], int64(expectedElement))
+			}
+		}
+	}
+}
+
 func TestHashLiterals(t *testing.T) {
 	input := "let two = \"two\"; {\"one\": 10 - 9, two: 1 + 1, \"thr\" + \"ee\": 6 / 2, 4: 4, true: 5, false: 6};"
 	expected := map[object.HashKey]int64{
@@ -805,6 +2489,21 @@ func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
 	return true
 }
 
+func testFloatObject(t *testing.T, obj object.Object, expected float64) bool {
+	float, ok := obj.(*object.Float)
+	if !ok {
+		t.Errorf("[Test] Invalid object type: received %T, expected *object.Float", obj)
+		return false
+	}
+
+	if float.Value != expected {
+		t.Errorf("[Test] Invalid float value: received %g, expected %g", float.Value, expected)
+		return false
+	}
+
+	return true
+}
+
 func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
 	boolean, ok := obj.(*object.Boolean)
 	if !ok {
@@ -819,6 +2518,21 @@ func testBooleanObject(t *testing.T, obj object.Object, expected bool) bool {
 	return true
 }
 
+func testStringObject(t *testing.T, obj object.Object, expected string) bool {
+	str, ok := obj.(*object.String)
+	if !ok {
+		t.Errorf("[Test] Invalid object type: received %T, expected *object.String", obj)
+		return false
+	}
+
+	if str.Value != expected {
+		t.Errorf("[Test] Invalid string object value: received %s, expected %s", str.Value, expected)
+		return false
+	}
+
+	return true
+}
+
 func testNullObject(t *testing.T, obj object.Object) bool {
 	if obj != NULL {
 		t.Errorf("[Test] Invalid null object: received %T, expected: *evaluator.NULL", obj)
@@ -10,6 +10,8 @@ replace leonardjouve/lexer => ../lexer
 
 replace leonardjouve/parser => ../parser
 
+replace leonardjouve/diagnostic => ../diagnostic
+
 go 1.20
 
 require (
@@ -20,3 +22,5 @@ require (
 )
 
 require leonardjouve/token v0.0.0-00010101000000-000000000000
+
+require leonardjouve/diagnostic v0.0.0-00010101000000-000000000000 // indirect
@@ -53,12 +53,17 @@ func isMacroDefinition(statement ast.Statement) bool {
 		return false
 	}
 
+	if _, ok := letStatement.Name.(*ast.Identifier); !ok {
+		return false
+	}
+
 	_, ok = letStatement.Value.(*ast.MacroLiteral)
 	return ok
 }
 
 func addMacro(statement ast.Statement, env *object.Environement) {
 	letStatement, _ := statement.(*ast.LetStatement)
+	name, _ := letStatement.Name.(*ast.Identifier)
 	macroLiteral, _ := letStatement.Value.(*ast.MacroLiteral)
 
 	macro := &object.Macro{
@@ -67,7 +72,7 @@ func addMacro(statement ast.Statement, env *object.Environement) {
 		Env:        env,
 	}
 
-	env.Set(letStatement.Name.Value, macro)
+	env.Set(name.Value, macro)
 }
 
 func isMacroCall(expression *ast.CallExpression, env *object.Environement) *object.Macro {
@@ -51,6 +51,22 @@ func convertObjectToAstNode(obj object.Object) ast.Node {
 			},
 			Value: obj.Value,
 		}
+	case *object.Float:
+		return &ast.FloatLiteral{
+			Token: token.Token{
+				Type:    token.FLOAT,
+				Literal: token.TokenLiteral(obj.Inspect()),
+			},
+			Value: obj.Value,
+		}
+	case *object.String:
+		return &ast.StringLiteral{
+			Token: token.Token{
+				Type:    token.STRING,
+				Literal: token.TokenLiteral(obj.Value),
+			},
+			Value: obj.Value,
+		}
 	case *object.Boolean:
 		var tok token.Token
 		if obj.Value {
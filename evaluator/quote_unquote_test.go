@@ -93,6 +93,10 @@ func TestUnquote(t *testing.T) {
 			input:    "let quoted = quote(4 + 4); quote(unquote(4 + 4) + unquote(quoted));",
 			expected: "(8 + (4 + 4))",
 		},
+		{
+			input:    "let name = \"world\"; quote(\"hello ${unquote(name)}\");",
+			expected: "\"hello world\"",
+		},
 	}
 
 	for _, test := range tests {
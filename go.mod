@@ -14,12 +14,15 @@ replace leonardjouve/parser => ./parser
 
 replace leonardjouve/ast => ./ast
 
+replace leonardjouve/diagnostic => ./diagnostic
+
 go 1.20
 
 require leonardjouve/repl v0.0.0-00010101000000-000000000000
 
 require (
 	leonardjouve/ast v0.0.0-00010101000000-000000000000 // indirect
+	leonardjouve/diagnostic v0.0.0-00010101000000-000000000000 // indirect
 	leonardjouve/evaluator v0.0.0-00010101000000-000000000000 // indirect
 	leonardjouve/lexer v0.0.0-00010101000000-000000000000 // indirect
 	leonardjouve/object v0.0.0-00010101000000-000000000000 // indirect
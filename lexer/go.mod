@@ -2,6 +2,11 @@ module leonardjouve/lexer
 
 replace leonardjouve/token => ../token
 
+replace leonardjouve/diagnostic => ../diagnostic
+
 go 1.20
 
-require leonardjouve/token v0.0.0-00010101000000-000000000000
+require (
+	leonardjouve/diagnostic v0.0.0-00010101000000-000000000000
+	leonardjouve/token v0.0.0-00010101000000-000000000000
+)
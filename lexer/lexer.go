@@ -1,63 +1,383 @@
 package lexer
 
 import (
+	"fmt"
+	"io"
+	"leonardjouve/diagnostic"
 	"leonardjouve/token"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+const READ_SIZE = 4096
+
 type Lexer struct {
-	input        string
-	position     int
-	readPosition int
-	char         byte
+	filename       string
+	reader         io.Reader
+	buffer         []byte
+	bufferOffset   int
+	mark           int
+	readErr        error
+	errReported    bool
+	eof            bool
+	peeked         []token.Token
+	position       int
+	readPosition   int
+	char           rune
+	line           int
+	column         int
+	keepComments   bool
+	interpolations []interpolation
+	previous       token.Token
+	Diagnostics    []*diagnostic.Diagnostic
+}
+
+type interpolation struct {
+	start      token.Position
+	braceDepth int
 }
 
 func New(input string) *Lexer {
+	return NewWithFilename("", input)
+}
+
+func NewWithFilename(filename string, input string) *Lexer {
+	return NewReaderWithFilename(filename, strings.NewReader(input))
+}
+
+func NewReader(reader io.Reader) *Lexer {
+	return NewReaderWithFilename("", reader)
+}
+
+func NewReaderWithFilename(filename string, reader io.Reader) *Lexer {
 	return &Lexer{
-		input:        input,
-		position:     0,
-		readPosition: 0,
-		char:         0,
+		filename:       filename,
+		reader:         reader,
+		buffer:         make([]byte, 0, READ_SIZE),
+		bufferOffset:   0,
+		mark:           0,
+		readErr:        nil,
+		errReported:    false,
+		eof:            false,
+		peeked:         []token.Token{},
+		position:       0,
+		readPosition:   0,
+		char:           0,
+		line:           1,
+		column:         0,
+		keepComments:   false,
+		interpolations: []interpolation{},
+		Diagnostics:    []*diagnostic.Diagnostic{},
+	}
+}
+
+func (lexer *Lexer) KeepComments() {
+	lexer.keepComments = true
+}
+
+func (lexer *Lexer) Err() error {
+	return lexer.readErr
+}
+
+func (lexer *Lexer) Peek(n int) []token.Token {
+	for len(lexer.peeked) < n {
+		lexer.peeked = append(lexer.peeked, lexer.lexToken())
 	}
+	return lexer.peeked[:n]
 }
 
 func (lexer *Lexer) NextToken() token.Token {
+	if len(lexer.peeked) > 0 {
+		tok := lexer.peeked[0]
+		lexer.peeked = lexer.peeked[1:]
+		return tok
+	}
+	return lexer.lexToken()
+}
+
+func (lexer *Lexer) lexToken() token.Token {
+	lexer.readChar()
+
+	trivia, illegal := lexer.readTrivia()
+	if illegal != nil {
+		return *illegal
+	}
+
+	start := lexer.getPosition()
+	tok := lexer.readToken()
+	tok.Span = token.Span{
+		Start: start,
+		End:   lexer.getEndPosition(),
+	}
+
+	if lexer.keepComments {
+		tok.LeadingTrivia = trivia
+		if tok.Type != token.EOF && tok.Type != token.ILLEGAL {
+			tok.TrailingTrivia = lexer.readTrailingTrivia()
+		}
+	}
+	lexer.previous = tok
+
+	return tok
+}
+
+func (lexer *Lexer) addError(code diagnostic.Code, span token.Span, message string) *diagnostic.Diagnostic {
+	diag := &diagnostic.Diagnostic{
+		Severity: diagnostic.ERROR,
+		Code:     code,
+		Span:     span,
+		Message:  message,
+	}
+	lexer.Diagnostics = append(lexer.Diagnostics, diag)
+
+	return diag
+}
+
+func (lexer *Lexer) readTrivia() ([]token.Token, *token.Token) {
+	trivia := []token.Token{}
+
+	for {
+		lexer.skipWhitespace()
+
+		if lexer.char != '/' {
+			return trivia, nil
+		}
+
+		switch lexer.getNextChar() {
+		case '/':
+			trivia = append(trivia, lexer.readLineComment())
+		case '*':
+			comment, ok := lexer.readBlockComment()
+			if !ok {
+				comment.Type = token.ILLEGAL
+				diag := lexer.addError(diagnostic.UNTERMINATED_COMMENT, comment.Span, "Unterminated block comment")
+				diag.Fix = &diagnostic.Fix{
+					Message: "insert \"*/\"",
+					Span: token.Span{
+						Start: comment.Span.End,
+						End:   comment.Span.End,
+					},
+					Replacement: "*/",
+				}
+				return trivia, &comment
+			}
+			trivia = append(trivia, comment)
+		default:
+			return trivia, nil
+		}
+
+		lexer.readChar()
+	}
+}
+
+func (lexer *Lexer) readTrailingTrivia() []token.Token {
+	trivia := []token.Token{}
+
+	for {
+		for nextChar := lexer.getNextChar(); nextChar == ' ' || nextChar == '\t' || nextChar == '\r'; nextChar = lexer.getNextChar() {
+			lexer.readChar()
+		}
+
+		if lexer.getNextChar() != '/' || lexer.getCharAt(lexer.readPosition+1) != '/' {
+			return trivia
+		}
+
+		lexer.readChar()
+		trivia = append(trivia, lexer.readLineComment())
+	}
+}
+
+func (lexer *Lexer) readLineComment() token.Token {
+	start := lexer.getPosition()
+	for nextChar := lexer.getNextChar(); nextChar != '\n' && nextChar != 0; nextChar = lexer.getNextChar() {
+		lexer.readChar()
+	}
+
+	return token.Token{
+		Type:    token.COMMENT,
+		Literal: token.TokenLiteral(lexer.getInput(start.Offset, lexer.readPosition)),
+		Span: token.Span{
+			Start: start,
+			End:   lexer.getEndPosition(),
+		},
+	}
+}
+
+func (lexer *Lexer) readBlockComment() (token.Token, bool) {
+	start := lexer.getPosition()
 	lexer.readChar()
-	lexer.skipWhitespace()
 
+	depth := 1
+	for depth > 0 {
+		lexer.readChar()
+
+		switch {
+		case lexer.char == 0:
+			return token.Token{
+				Type:    token.COMMENT,
+				Literal: token.TokenLiteral(lexer.getInput(start.Offset, lexer.position)),
+				Span: token.Span{
+					Start: start,
+					End:   lexer.getPosition(),
+				},
+			}, false
+		case lexer.char == '/' && lexer.getNextChar() == '*':
+			lexer.readChar()
+			depth += 1
+		case lexer.char == '*' && lexer.getNextChar() == '/':
+			lexer.readChar()
+			depth -= 1
+		}
+	}
+
+	return token.Token{
+		Type:    token.COMMENT,
+		Literal: token.TokenLiteral(lexer.getInput(start.Offset, lexer.readPosition)),
+		Span: token.Span{
+			Start: start,
+			End:   lexer.getEndPosition(),
+		},
+	}, true
+}
+
+func (lexer *Lexer) followsOperand() bool {
+	if lexer.previous.Span.End.Offset != lexer.position {
+		return false
+	}
+
+	switch lexer.previous.Type {
+	case token.IDENTIFIER, token.INT, token.FLOAT, token.STRING, token.STRING_TAIL, token.TRUE, token.FALSE, token.RPAREN, token.RBRACKET, token.RBRACE:
+		return true
+	default:
+		return false
+	}
+}
+
+func (lexer *Lexer) readToken() token.Token {
 	var tokenType token.TokenType
 	tokenLiteral := token.TokenLiteral(lexer.char)
 
 	switch lexer.char {
 	case '+':
-		tokenType = token.PLUS
+		if nextChar := lexer.getNextChar(); nextChar == '=' {
+			tokenType = token.PLUS_ASSIGN
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		} else {
+			tokenType = token.PLUS
+		}
 	case '(':
 		tokenType = token.LPAREN
 	case ')':
 		tokenType = token.RPAREN
 	case '{':
 		tokenType = token.LBRACE
+		if depth := len(lexer.interpolations); depth > 0 {
+			lexer.interpolations[depth-1].braceDepth += 1
+		}
 	case '}':
 		tokenType = token.RBRACE
+		if depth := len(lexer.interpolations); depth > 0 {
+			if lexer.interpolations[depth-1].braceDepth == 0 {
+				start := lexer.interpolations[depth-1].start
+				lexer.interpolations = lexer.interpolations[:depth-1]
+				tokenType, tokenLiteral = lexer.readStringPart(start, token.STRING_MIDDLE, token.STRING_TAIL)
+			} else {
+				lexer.interpolations[depth-1].braceDepth -= 1
+			}
+		}
 	case ',':
 		tokenType = token.COMMA
 	case ';':
 		tokenType = token.SEMICOLON
 	case '-':
-		tokenType = token.MINUS
+		if nextChar := lexer.getNextChar(); nextChar == '=' {
+			tokenType = token.MINUS_ASSIGN
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		} else {
+			tokenType = token.MINUS
+		}
 	case '/':
-		tokenType = token.SLASH
+		if nextChar := lexer.getNextChar(); nextChar == '=' {
+			tokenType = token.SLASH_ASSIGN
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		} else {
+			tokenType = token.SLASH
+		}
 	case '*':
-		tokenType = token.ASTERISX
+		if nextChar := lexer.getNextChar(); nextChar == '=' {
+			tokenType = token.ASTERISX_ASSIGN
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		} else {
+			tokenType = token.ASTERISX
+		}
+	case '%':
+		tokenType = token.MODULO
 	case '<':
-		tokenType = token.LR
+		switch lexer.getNextChar() {
+		case '=':
+			tokenType = token.LR_EQUAL
+			tokenLiteral += token.TokenLiteral(lexer.getNextChar())
+			lexer.readChar()
+		case '<':
+			tokenType = token.LEFT_SHIFT
+			tokenLiteral += token.TokenLiteral(lexer.getNextChar())
+			lexer.readChar()
+		default:
+			tokenType = token.LR
+		}
 	case '>':
-		tokenType = token.GR
+		switch lexer.getNextChar() {
+		case '=':
+			tokenType = token.GR_EQUAL
+			tokenLiteral += token.TokenLiteral(lexer.getNextChar())
+			lexer.readChar()
+		case '>':
+			tokenType = token.RIGHT_SHIFT
+			tokenLiteral += token.TokenLiteral(lexer.getNextChar())
+			lexer.readChar()
+		default:
+			tokenType = token.GR
+		}
+	case '&':
+		if nextChar := lexer.getNextChar(); nextChar == '&' {
+			tokenType = token.AND
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		} else {
+			tokenType = token.BITWISE_AND
+		}
+	case '|':
+		switch nextChar := lexer.getNextChar(); nextChar {
+		case '|':
+			tokenType = token.OR
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		case '>':
+			tokenType = token.PIPELINE
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		default:
+			tokenType = token.BITWISE_OR
+		}
+	case '^':
+		tokenType = token.BITWISE_XOR
 	case '=':
-		if nextChar := lexer.getNextChar(); nextChar == '=' {
+		switch nextChar := lexer.getNextChar(); nextChar {
+		case '=':
 			tokenType = token.EQUAL
 			tokenLiteral += token.TokenLiteral(nextChar)
 			lexer.readChar()
-		} else {
+		case '>':
+			tokenType = token.ARROW
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		default:
 			tokenType = token.ASSIGN
 		}
 	case '!':
@@ -69,25 +389,59 @@ func (lexer *Lexer) NextToken() token.Token {
 			tokenType = token.BANG
 		}
 	case '"':
-		tokenType = token.STRING
-		tokenLiteral = lexer.readString()
+		tokenType, tokenLiteral = lexer.readStringPart(lexer.getPosition(), token.STRING_HEAD, token.STRING)
+	case '`':
+		tokenType, tokenLiteral = lexer.readRawString()
 	case '[':
 		tokenType = token.LBRACKET
 	case ']':
 		tokenType = token.RBRACKET
+	case '?':
+		switch nextChar := lexer.getNextChar(); nextChar {
+		case '?':
+			tokenType = token.NULLISH
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		case '[':
+			if !lexer.followsOperand() {
+				tokenType = token.QUESTION
+				break
+			}
+			tokenType = token.OPTIONAL_INDEX
+			tokenLiteral += token.TokenLiteral(nextChar)
+			lexer.readChar()
+		default:
+			tokenType = token.QUESTION
+		}
 	case ':':
 		tokenType = token.COLON
+	case '.':
+		if isDigit(lexer.getNextChar()) {
+			tokenType, tokenLiteral = lexer.readNumber()
+		} else if lexer.getNextChar() == '.' && lexer.getCharAt(lexer.readPosition+1) == '.' {
+			tokenType = token.ELLIPSIS
+			tokenLiteral += ".."
+			lexer.readChar()
+			lexer.readChar()
+		} else {
+			tokenType = lexer.readIllegal()
+		}
 	case 0:
 		tokenType = token.EOF
+		if lexer.readErr != nil && !lexer.errReported {
+			lexer.errReported = true
+			tokenType = token.ILLEGAL
+			tokenLiteral = ""
+			lexer.addError(diagnostic.READ_ERROR, token.Span{Start: lexer.getPosition(), End: lexer.getPosition()}, fmt.Sprintf("Failed to read input: %s", lexer.readErr))
+		}
 	default:
 		if isLetter(lexer.char) {
 			tokenLiteral = lexer.readIdentifier()
 			tokenType = token.LookupIdentifier(tokenLiteral)
 		} else if isDigit(lexer.char) {
-			tokenType = token.INT
-			tokenLiteral = lexer.readNumber()
+			tokenType, tokenLiteral = lexer.readNumber()
 		} else {
-			tokenType = token.ILLEGAL
+			tokenType = lexer.readIllegal()
 		}
 	}
 
@@ -96,60 +450,306 @@ func (lexer *Lexer) NextToken() token.Token {
 
 func (lexer *Lexer) readIdentifier() token.TokenLiteral {
 	position := lexer.position
-	for isLetter(lexer.char) && isLetter(lexer.getNextChar()) {
+	for isLetter(lexer.getNextChar()) || isDigit(lexer.getNextChar()) {
 		lexer.readChar()
 	}
-	return token.TokenLiteral(lexer.input[position:lexer.readPosition])
+	return token.TokenLiteral(lexer.getInput(position, lexer.readPosition))
 }
 
-func (lexer *Lexer) readNumber() token.TokenLiteral {
+func (lexer *Lexer) readNumber() (token.TokenType, token.TokenLiteral) {
 	position := lexer.position
-	for isDigit(lexer.char) && isDigit(lexer.getNextChar()) {
+	var tokenType token.TokenType = token.INT
+
+	if lexer.char == '0' && isBasePrefix(lexer.getNextChar()) {
+		lexer.readChar()
+		for nextChar := lexer.getNextChar(); isHexDigit(nextChar) || nextChar == '_'; nextChar = lexer.getNextChar() {
+			lexer.readChar()
+		}
+		return tokenType, token.TokenLiteral(lexer.getInput(position, lexer.readPosition))
+	}
+
+	if lexer.char == '.' {
+		tokenType = token.FLOAT
+	}
+	lexer.readDigits()
+
+	if tokenType == token.INT && lexer.getNextChar() == '.' && isDigit(lexer.getCharAt(lexer.readPosition+1)) {
+		tokenType = token.FLOAT
+		lexer.readChar()
+		lexer.readDigits()
+	}
+
+	if nextChar := lexer.getNextChar(); nextChar == 'e' || nextChar == 'E' {
+		exponentPosition := lexer.readPosition + 1
+		if sign := lexer.getCharAt(exponentPosition); sign == '+' || sign == '-' {
+			exponentPosition += 1
+		}
+
+		if isDigit(lexer.getCharAt(exponentPosition)) {
+			tokenType = token.FLOAT
+			for lexer.readPosition < exponentPosition {
+				lexer.readChar()
+			}
+			lexer.readDigits()
+		}
+	}
+
+	return tokenType, token.TokenLiteral(lexer.getInput(position, lexer.readPosition))
+}
+
+func (lexer *Lexer) readDigits() {
+	for nextChar := lexer.getNextChar(); isDigit(nextChar) || nextChar == '_'; nextChar = lexer.getNextChar() {
+		lexer.readChar()
+	}
+}
+
+func (lexer *Lexer) readStringPart(start token.Position, interpolationType token.TokenType, endType token.TokenType) (token.TokenType, token.TokenLiteral) {
+	var tokenType token.TokenType = endType
+	var out strings.Builder
+
+	for {
+		lexer.readChar()
+
+		switch lexer.char {
+		case '"':
+			return tokenType, token.TokenLiteral(out.String())
+		case '$':
+			if lexer.getNextChar() != '{' {
+				out.WriteRune(lexer.char)
+				continue
+			}
+			lexer.readChar()
+			lexer.interpolations = append(lexer.interpolations, interpolation{
+				start:      start,
+				braceDepth: 0,
+			})
+			if tokenType == token.ILLEGAL {
+				return tokenType, token.TokenLiteral(out.String())
+			}
+			return interpolationType, token.TokenLiteral(out.String())
+		case 0:
+			lexer.addUnterminatedStringError(start, "\"")
+			return token.ILLEGAL, token.TokenLiteral(lexer.getInput(start.Offset, lexer.position))
+		case '\\':
+			if !lexer.readEscape(&out) {
+				tokenType = token.ILLEGAL
+			}
+		default:
+			out.WriteRune(lexer.char)
+		}
+	}
+}
+
+func (lexer *Lexer) readEscape(out *strings.Builder) bool {
+	start := lexer.getPosition()
+	lexer.readChar()
+
+	switch lexer.char {
+	case 'n':
+		out.WriteByte('\n')
+	case 't':
+		out.WriteByte('\t')
+	case 'r':
+		out.WriteByte('\r')
+	case '\\':
+		out.WriteByte('\\')
+	case '"':
+		out.WriteByte('"')
+	case '$':
+		out.WriteByte('$')
+	case 'u':
+		return lexer.readUnicodeEscape(start, out)
+	case 0:
+		return false
+	default:
+		lexer.addError(diagnostic.INVALID_ESCAPE, token.Span{Start: start, End: lexer.getEndPosition()}, fmt.Sprintf("Invalid escape sequence \"\\%c\"", lexer.char))
+		return false
+	}
+
+	return true
+}
+
+func (lexer *Lexer) readUnicodeEscape(start token.Position, out *strings.Builder) bool {
+	if lexer.getNextChar() != '{' {
+		lexer.addError(diagnostic.INVALID_ESCAPE, token.Span{Start: start, End: lexer.getEndPosition()}, "Invalid unicode escape sequence: expected \"{\" after \"\\u\"")
+		return false
+	}
+	lexer.readChar()
+
+	digitsPosition := lexer.readPosition
+	for isHexDigit(lexer.getNextChar()) {
 		lexer.readChar()
 	}
-	return token.TokenLiteral(lexer.input[position:lexer.readPosition])
+	digits := lexer.getInput(digitsPosition, lexer.readPosition)
+
+	if lexer.getNextChar() != '}' {
+		lexer.addError(diagnostic.INVALID_ESCAPE, token.Span{Start: start, End: lexer.getEndPosition()}, "Invalid unicode escape sequence: expected \"}\"")
+		return false
+	}
+	lexer.readChar()
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || len(digits) > 6 || !utf8.ValidRune(rune(value)) {
+		lexer.addError(diagnostic.INVALID_ESCAPE, token.Span{Start: start, End: lexer.getEndPosition()}, fmt.Sprintf("Invalid unicode code point \"%s\"", digits))
+		return false
+	}
+	out.WriteRune(rune(value))
+
+	return true
 }
 
-func (lexer *Lexer) readString() token.TokenLiteral {
-	position := lexer.position + 1
+func (lexer *Lexer) readRawString() (token.TokenType, token.TokenLiteral) {
+	start := lexer.getPosition()
+
 	for {
 		lexer.readChar()
-		if lexer.char == '"' || lexer.char == 0 {
-			break
+
+		switch lexer.char {
+		case '`':
+			return token.STRING, token.TokenLiteral(lexer.getInput(start.Offset+1, lexer.position))
+		case 0:
+			lexer.addUnterminatedStringError(start, "`")
+			return token.ILLEGAL, token.TokenLiteral(lexer.getInput(start.Offset, lexer.position))
 		}
 	}
+}
+
+func (lexer *Lexer) addUnterminatedStringError(start token.Position, quote string) {
+	diag := lexer.addError(diagnostic.UNTERMINATED_STRING, token.Span{Start: start, End: lexer.getPosition()}, "Unterminated string literal")
+	diag.Fix = &diagnostic.Fix{
+		Message: fmt.Sprintf("insert %q", quote),
+		Span: token.Span{
+			Start: lexer.getPosition(),
+			End:   lexer.getPosition(),
+		},
+		Replacement: quote,
+	}
+}
+
+func (lexer *Lexer) readIllegal() token.TokenType {
+	span := token.Span{
+		Start: lexer.getPosition(),
+		End:   lexer.getEndPosition(),
+	}
+	lexer.addError(diagnostic.ILLEGAL_CHARACTER, span, fmt.Sprintf("Illegal character %q", string(lexer.char)))
 
-	return token.TokenLiteral(lexer.input[position:lexer.position])
+	return token.ILLEGAL
+}
+
+func (lexer *Lexer) getPosition() token.Position {
+	return token.Position{
+		Filename: lexer.filename,
+		Offset:   lexer.position,
+		Line:     lexer.line,
+		Column:   lexer.column,
+	}
+}
+
+func (lexer *Lexer) getEndPosition() token.Position {
+	position := lexer.getPosition()
+	if lexer.position < lexer.bufferOffset+len(lexer.buffer) {
+		position.Offset = lexer.readPosition
+		position.Column += 1
+	}
+	return position
 }
 
 func (lexer *Lexer) readChar() {
-	if lexer.readPosition >= len(lexer.input) {
-		lexer.char = 0
+	if lexer.char == '\n' {
+		lexer.line += 1
+		lexer.column = 1
 	} else {
-		lexer.char = lexer.getNextChar()
+		lexer.column += 1
+	}
+
+	char, width := lexer.decodeCharAt(lexer.readPosition)
+	if width == 0 {
+		width = 1
 	}
+	lexer.char = char
 	lexer.position = lexer.readPosition
-	lexer.readPosition += 1
+	lexer.readPosition += width
 }
 
-func (lexer *Lexer) getNextChar() byte {
-	if lexer.readPosition >= len(lexer.input) {
-		return 0
-	} else {
-		return lexer.input[lexer.readPosition]
+func (lexer *Lexer) getNextChar() rune {
+	return lexer.getCharAt(lexer.readPosition)
+}
+
+func (lexer *Lexer) getCharAt(position int) rune {
+	char, _ := lexer.decodeCharAt(position)
+	return char
+}
+
+func (lexer *Lexer) decodeCharAt(position int) (rune, int) {
+	lexer.fill(position + utf8.UTFMax)
+	if position >= lexer.bufferOffset+len(lexer.buffer) {
+		return 0, 0
+	}
+	return utf8.DecodeRune(lexer.buffer[position-lexer.bufferOffset:])
+}
+
+func (lexer *Lexer) getInput(start int, end int) string {
+	return string(lexer.buffer[start-lexer.bufferOffset : end-lexer.bufferOffset])
+}
+
+func (lexer *Lexer) fill(end int) {
+	for !lexer.eof && lexer.bufferOffset+len(lexer.buffer) < end {
+		if discarded := lexer.mark - lexer.bufferOffset; discarded > 0 {
+			kept := copy(lexer.buffer, lexer.buffer[discarded:])
+			lexer.buffer = lexer.buffer[:kept]
+			lexer.bufferOffset = lexer.mark
+		}
+
+		length := len(lexer.buffer)
+		if cap(lexer.buffer)-length < READ_SIZE {
+			buffer := make([]byte, length, 2*cap(lexer.buffer)+READ_SIZE)
+			copy(buffer, lexer.buffer)
+			lexer.buffer = buffer
+		}
+
+		n, err := lexer.reader.Read(lexer.buffer[length : length+READ_SIZE])
+		lexer.buffer = lexer.buffer[:length+n]
+		if err != nil {
+			lexer.eof = true
+			if err != io.EOF {
+				lexer.readErr = err
+			}
+		}
 	}
 }
 
 func (lexer *Lexer) skipWhitespace() {
+	lexer.setMark(lexer.position)
 	for lexer.char == ' ' || lexer.char == '\n' || lexer.char == '\r' || lexer.char == '\t' {
 		lexer.readChar()
+		lexer.setMark(lexer.position)
+	}
+}
+
+func (lexer *Lexer) setMark(position int) {
+	if len(lexer.interpolations) > 0 && lexer.interpolations[0].start.Offset < position {
+		position = lexer.interpolations[0].start.Offset
 	}
+	lexer.mark = position
 }
 
-func isLetter(char byte) bool {
-	return ('a' <= char && char <= 'z') || ('A' <= char && char <= 'Z') || char == '_'
+func isLetter(char rune) bool {
+	return unicode.IsLetter(char) || char == '_'
 }
 
-func isDigit(char byte) bool {
+func isDigit(char rune) bool {
 	return '0' <= char && char <= '9'
 }
+
+func isBasePrefix(char rune) bool {
+	switch char {
+	case 'x', 'X', 'o', 'O', 'b', 'B':
+		return true
+	default:
+		return false
+	}
+}
+
+func isHexDigit(char rune) bool {
+	return isDigit(char) || ('a' <= char && char <= 'f') || ('A' <= char && char <= 'F')
+}
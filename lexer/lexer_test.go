@@ -1,8 +1,12 @@
 package lexer
 
 import (
+	"leonardjouve/diagnostic"
 	"leonardjouve/token"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func TestNextToken(t *testing.T) {
@@ -16,7 +20,7 @@ func TestNextToken(t *testing.T) {
 	
 	let result = add(five, ten);
 	
-	!-/*5;
+	!-/ *5;
 	5 < 10 > 5;
 	
 	if (5 < 10) {
@@ -32,6 +36,15 @@ func TestNextToken(t *testing.T) {
 	[1, 2];
 	{"foo": "bar"};
 	macro(x, y) {x + y;};
+	a <= b >= c && d || e % f;
+	g & h | i ^ j << k >> l;
+	m += 1; m -= 1; m *= 2; m /= 2;
+	match (n) { 1 => a, _ => b };
+	[x, ...xs];
+	xs |> f;
+	a ?? b ? c : d?[e];
+	c ?[e] : f;
+	try { throw f; } catch (g) {} finally {}
 	`
 	tests := []token.Token{
 		{Type: token.LET, Literal: "let"},
@@ -136,6 +149,233 @@ func TestNextToken(t *testing.T) {
 		{Type: token.SEMICOLON, Literal: ";"},
 		{Type: token.RBRACE, Literal: "}"},
 		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "a"},
+		{Type: token.LR_EQUAL, Literal: "<="},
+		{Type: token.IDENTIFIER, Literal: "b"},
+		{Type: token.GR_EQUAL, Literal: ">="},
+		{Type: token.IDENTIFIER, Literal: "c"},
+		{Type: token.AND, Literal: "&&"},
+		{Type: token.IDENTIFIER, Literal: "d"},
+		{Type: token.OR, Literal: "||"},
+		{Type: token.IDENTIFIER, Literal: "e"},
+		{Type: token.MODULO, Literal: "%"},
+		{Type: token.IDENTIFIER, Literal: "f"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "g"},
+		{Type: token.BITWISE_AND, Literal: "&"},
+		{Type: token.IDENTIFIER, Literal: "h"},
+		{Type: token.BITWISE_OR, Literal: "|"},
+		{Type: token.IDENTIFIER, Literal: "i"},
+		{Type: token.BITWISE_XOR, Literal: "^"},
+		{Type: token.IDENTIFIER, Literal: "j"},
+		{Type: token.LEFT_SHIFT, Literal: "<<"},
+		{Type: token.IDENTIFIER, Literal: "k"},
+		{Type: token.RIGHT_SHIFT, Literal: ">>"},
+		{Type: token.IDENTIFIER, Literal: "l"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "m"},
+		{Type: token.PLUS_ASSIGN, Literal: "+="},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "m"},
+		{Type: token.MINUS_ASSIGN, Literal: "-="},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "m"},
+		{Type: token.ASTERISX_ASSIGN, Literal: "*="},
+		{Type: token.INT, Literal: "2"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "m"},
+		{Type: token.SLASH_ASSIGN, Literal: "/="},
+		{Type: token.INT, Literal: "2"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.MATCH, Literal: "match"},
+		{Type: token.LPAREN, Literal: "("},
+		{Type: token.IDENTIFIER, Literal: "n"},
+		{Type: token.RPAREN, Literal: ")"},
+		{Type: token.LBRACE, Literal: "{"},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.ARROW, Literal: "=>"},
+		{Type: token.IDENTIFIER, Literal: "a"},
+		{Type: token.COMMA, Literal: ","},
+		{Type: token.IDENTIFIER, Literal: "_"},
+		{Type: token.ARROW, Literal: "=>"},
+		{Type: token.IDENTIFIER, Literal: "b"},
+		{Type: token.RBRACE, Literal: "}"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.LBRACKET, Literal: "["},
+		{Type: token.IDENTIFIER, Literal: "x"},
+		{Type: token.COMMA, Literal: ","},
+		{Type: token.ELLIPSIS, Literal: "..."},
+		{Type: token.IDENTIFIER, Literal: "xs"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "xs"},
+		{Type: token.PIPELINE, Literal: "|>"},
+		{Type: token.IDENTIFIER, Literal: "f"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "a"},
+		{Type: token.NULLISH, Literal: "??"},
+		{Type: token.IDENTIFIER, Literal: "b"},
+		{Type: token.QUESTION, Literal: "?"},
+		{Type: token.IDENTIFIER, Literal: "c"},
+		{Type: token.COLON, Literal: ":"},
+		{Type: token.IDENTIFIER, Literal: "d"},
+		{Type: token.OPTIONAL_INDEX, Literal: "?["},
+		{Type: token.IDENTIFIER, Literal: "e"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "c"},
+		{Type: token.QUESTION, Literal: "?"},
+		{Type: token.LBRACKET, Literal: "["},
+		{Type: token.IDENTIFIER, Literal: "e"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.COLON, Literal: ":"},
+		{Type: token.IDENTIFIER, Literal: "f"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.TRY, Literal: "try"},
+		{Type: token.LBRACE, Literal: "{"},
+		{Type: token.THROW, Literal: "throw"},
+		{Type: token.IDENTIFIER, Literal: "f"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.RBRACE, Literal: "}"},
+		{Type: token.CATCH, Literal: "catch"},
+		{Type: token.LPAREN, Literal: "("},
+		{Type: token.IDENTIFIER, Literal: "g"},
+		{Type: token.RPAREN, Literal: ")"},
+		{Type: token.LBRACE, Literal: "{"},
+		{Type: token.RBRACE, Literal: "}"},
+		{Type: token.FINALLY, Literal: "finally"},
+		{Type: token.LBRACE, Literal: "{"},
+		{Type: token.RBRACE, Literal: "}"},
+		{Type: token.EOF, Literal: "\x00"},
+	}
+
+	lexer := New(input)
+
+	for i, test := range tests {
+		tok := lexer.NextToken()
+
+		if tok.Type != test.Type {
+			t.Fatalf("[Test %d] Invalid token type: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+
+		if tok.Literal != test.Literal {
+			t.Fatalf("[Test %d] Invalid token literal: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	input := "let x = 5;\n  x + \"ab\";"
+	tests := []token.Span{
+		{Start: token.Position{Filename: "test", Offset: 0, Line: 1, Column: 1}, End: token.Position{Filename: "test", Offset: 3, Line: 1, Column: 4}},
+		{Start: token.Position{Filename: "test", Offset: 4, Line: 1, Column: 5}, End: token.Position{Filename: "test", Offset: 5, Line: 1, Column: 6}},
+		{Start: token.Position{Filename: "test", Offset: 6, Line: 1, Column: 7}, End: token.Position{Filename: "test", Offset: 7, Line: 1, Column: 8}},
+		{Start: token.Position{Filename: "test", Offset: 8, Line: 1, Column: 9}, End: token.Position{Filename: "test", Offset: 9, Line: 1, Column: 10}},
+		{Start: token.Position{Filename: "test", Offset: 9, Line: 1, Column: 10}, End: token.Position{Filename: "test", Offset: 10, Line: 1, Column: 11}},
+		{Start: token.Position{Filename: "test", Offset: 13, Line: 2, Column: 3}, End: token.Position{Filename: "test", Offset: 14, Line: 2, Column: 4}},
+		{Start: token.Position{Filename: "test", Offset: 15, Line: 2, Column: 5}, End: token.Position{Filename: "test", Offset: 16, Line: 2, Column: 6}},
+		{Start: token.Position{Filename: "test", Offset: 17, Line: 2, Column: 7}, End: token.Position{Filename: "test", Offset: 21, Line: 2, Column: 11}},
+		{Start: token.Position{Filename: "test", Offset: 21, Line: 2, Column: 11}, End: token.Position{Filename: "test", Offset: 22, Line: 2, Column: 12}},
+		{Start: token.Position{Filename: "test", Offset: 22, Line: 2, Column: 12}, End: token.Position{Filename: "test", Offset: 22, Line: 2, Column: 12}},
+	}
+
+	lexer := NewWithFilename("test", input)
+
+	for i, test := range tests {
+		tok := lexer.NextToken()
+
+		if tok.Span != test {
+			t.Fatalf("[Test %d] Invalid token span: received %+v, expected %+v", i, tok.Span, test)
+		}
+	}
+}
+
+func TestUnicode(t *testing.T) {
+	input := "let café = \"é😀\"; naïve ≠"
+	tests := []token.Token{
+		{
+			Type:    token.LET,
+			Literal: "let",
+			Span:    token.Span{Start: token.Position{Offset: 0, Line: 1, Column: 1}, End: token.Position{Offset: 3, Line: 1, Column: 4}},
+		},
+		{
+			Type:    token.IDENTIFIER,
+			Literal: "café",
+			Span:    token.Span{Start: token.Position{Offset: 4, Line: 1, Column: 5}, End: token.Position{Offset: 9, Line: 1, Column: 9}},
+		},
+		{
+			Type:    token.ASSIGN,
+			Literal: "=",
+			Span:    token.Span{Start: token.Position{Offset: 10, Line: 1, Column: 10}, End: token.Position{Offset: 11, Line: 1, Column: 11}},
+		},
+		{
+			Type:    token.STRING,
+			Literal: "é😀",
+			Span:    token.Span{Start: token.Position{Offset: 12, Line: 1, Column: 12}, End: token.Position{Offset: 20, Line: 1, Column: 16}},
+		},
+		{
+			Type:    token.SEMICOLON,
+			Literal: ";",
+			Span:    token.Span{Start: token.Position{Offset: 20, Line: 1, Column: 16}, End: token.Position{Offset: 21, Line: 1, Column: 17}},
+		},
+		{
+			Type:    token.IDENTIFIER,
+			Literal: "naïve",
+			Span:    token.Span{Start: token.Position{Offset: 22, Line: 1, Column: 18}, End: token.Position{Offset: 28, Line: 1, Column: 23}},
+		},
+		{
+			Type:    token.ILLEGAL,
+			Literal: "≠",
+			Span:    token.Span{Start: token.Position{Offset: 29, Line: 1, Column: 24}, End: token.Position{Offset: 32, Line: 1, Column: 25}},
+		},
+		{
+			Type:    token.EOF,
+			Literal: "\x00",
+			Span:    token.Span{Start: token.Position{Offset: 32, Line: 1, Column: 25}, End: token.Position{Offset: 32, Line: 1, Column: 25}},
+		},
+	}
+
+	lexer := New(input)
+
+	for i, test := range tests {
+		tok := lexer.NextToken()
+
+		if tok.Type != test.Type || tok.Literal != test.Literal {
+			t.Fatalf("[Test %d] Invalid token: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+
+		if tok.Span != test.Span {
+			t.Fatalf("[Test %d] Invalid token span: received %+v, expected %+v", i, tok.Span, test.Span)
+		}
+	}
+
+	if diagnosticAmount := len(lexer.Diagnostics); diagnosticAmount != 1 {
+		t.Fatalf("[Test] Invalid diagnostic amount: received %d, expected 1", diagnosticAmount)
+	}
+
+	if expectedMessage := "Illegal character \"≠\""; lexer.Diagnostics[0].Message != expectedMessage {
+		t.Errorf("[Test] Invalid diagnostic message: received %s, expected %s", lexer.Diagnostics[0].Message, expectedMessage)
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `// leading comment
+let x = 5; // trailing comment
+/* block /* nested */ comment */ x / 2;
+/* unterminated`
+	tests := []token.Token{
+		{Type: token.LET, Literal: "let"},
+		{Type: token.IDENTIFIER, Literal: "x"},
+		{Type: token.ASSIGN, Literal: "="},
+		{Type: token.INT, Literal: "5"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.IDENTIFIER, Literal: "x"},
+		{Type: token.SLASH, Literal: "/"},
+		{Type: token.INT, Literal: "2"},
+		{Type: token.SEMICOLON, Literal: ";"},
+		{Type: token.ILLEGAL, Literal: "/* unterminated"},
 		{Type: token.EOF, Literal: "\x00"},
 	}
 
@@ -151,5 +391,365 @@ func TestNextToken(t *testing.T) {
 		if tok.Literal != test.Literal {
 			t.Fatalf("[Test %d] Invalid token literal: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
 		}
+
+		if len(tok.LeadingTrivia) != 0 || len(tok.TrailingTrivia) != 0 {
+			t.Fatalf("[Test %d] Invalid token trivia: received %v %v, expected none", i, tok.LeadingTrivia, tok.TrailingTrivia)
+		}
+	}
+
+	expectedDiagnosticAmount := 1
+	if diagnosticAmount := len(lexer.Diagnostics); diagnosticAmount != expectedDiagnosticAmount {
+		t.Fatalf("[Test] Invalid diagnostic amount: received %d, expected %d", diagnosticAmount, expectedDiagnosticAmount)
+	}
+
+	expectedDiagnostic := "4:1: error[E0004]: Unterminated block comment"
+	if diag := lexer.Diagnostics[0].Error(); diag != expectedDiagnostic {
+		t.Errorf("[Test] Invalid diagnostic: received %s, expected %s", diag, expectedDiagnostic)
+	}
+}
+
+func TestCommentTrivia(t *testing.T) {
+	type CommentTriviaTest struct {
+		tokenType token.TokenType
+		leading   []token.TokenLiteral
+		trailing  []token.TokenLiteral
+	}
+	input := `// first
+// second
+let x = 5; // five
+/* block */ x // end`
+	tests := []CommentTriviaTest{
+		{
+			tokenType: token.LET,
+			leading:   []token.TokenLiteral{"// first", "// second"},
+		},
+		{tokenType: token.IDENTIFIER},
+		{tokenType: token.ASSIGN},
+		{tokenType: token.INT},
+		{
+			tokenType: token.SEMICOLON,
+			trailing:  []token.TokenLiteral{"// five"},
+		},
+		{
+			tokenType: token.IDENTIFIER,
+			leading:   []token.TokenLiteral{"/* block */"},
+			trailing:  []token.TokenLiteral{"// end"},
+		},
+		{tokenType: token.EOF},
+	}
+
+	lexer := New(input)
+	lexer.KeepComments()
+
+	for i, test := range tests {
+		tok := lexer.NextToken()
+
+		if tok.Type != test.tokenType {
+			t.Fatalf("[Test %d] Invalid token type: received %q, expected %q", i, tok.Type, test.tokenType)
+		}
+
+		testTrivia(t, i, tok.LeadingTrivia, test.leading)
+		testTrivia(t, i, tok.TrailingTrivia, test.trailing)
+	}
+}
+
+func testTrivia(t *testing.T, i int, trivia []token.Token, expected []token.TokenLiteral) {
+	if len(trivia) != len(expected) {
+		t.Fatalf("[Test %d] Invalid trivia amount: received %d, expected %d", i, len(trivia), len(expected))
+	}
+
+	for j, comment := range trivia {
+		if comment.Type != token.COMMENT {
+			t.Errorf("[Test %d] Invalid trivia type: received %q, expected %q", i, comment.Type, token.COMMENT)
+		}
+
+		if comment.Literal != expected[j] {
+			t.Errorf("[Test %d] Invalid trivia literal: received %q, expected %q", i, comment.Literal, expected[j])
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	input := "5 1.5 .5 1e-3 2E10 3.25e+2 7. 1.x 0xFF 0o17 0b1010 1_000_000 1_000.5 item2 x_1y"
+	tests := []token.Token{
+		{Type: token.INT, Literal: "5"},
+		{Type: token.FLOAT, Literal: "1.5"},
+		{Type: token.FLOAT, Literal: ".5"},
+		{Type: token.FLOAT, Literal: "1e-3"},
+		{Type: token.FLOAT, Literal: "2E10"},
+		{Type: token.FLOAT, Literal: "3.25e+2"},
+		{Type: token.INT, Literal: "7"},
+		{Type: token.ILLEGAL, Literal: "."},
+		{Type: token.INT, Literal: "1"},
+		{Type: token.ILLEGAL, Literal: "."},
+		{Type: token.IDENTIFIER, Literal: "x"},
+		{Type: token.INT, Literal: "0xFF"},
+		{Type: token.INT, Literal: "0o17"},
+		{Type: token.INT, Literal: "0b1010"},
+		{Type: token.INT, Literal: "1_000_000"},
+		{Type: token.FLOAT, Literal: "1_000.5"},
+		{Type: token.IDENTIFIER, Literal: "item2"},
+		{Type: token.IDENTIFIER, Literal: "x_1y"},
+		{Type: token.EOF, Literal: "\x00"},
+	}
+
+	lexer := New(input)
+
+	for i, test := range tests {
+		tok := lexer.NextToken()
+
+		if tok.Type != test.Type {
+			t.Fatalf("[Test %d] Invalid token type: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+
+		if tok.Literal != test.Literal {
+			t.Fatalf("[Test %d] Invalid token literal: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+	}
+}
+
+func TestStrings(t *testing.T) {
+	input := "\"a\\\"b\" \"line\\nnext\\ttab\\\\\" \"\\u{e9}\\u{1F600}\" `raw \\n ${x}` \"multi\nline\" `multi\nraw` \"bad \\q escape\" \"unterminated"
+	tests := []token.Token{
+		{Type: token.STRING, Literal: "a\"b"},
+		{Type: token.STRING, Literal: "line\nnext\ttab\\"},
+		{Type: token.STRING, Literal: "\u00e9\U0001F600"},
+		{Type: token.STRING, Literal: "raw \\n ${x}"},
+		{Type: token.STRING, Literal: "multi\nline"},
+		{Type: token.STRING, Literal: "multi\nraw"},
+		{Type: token.ILLEGAL, Literal: "bad  escape"},
+		{Type: token.ILLEGAL, Literal: "\"unterminated"},
+		{Type: token.EOF, Literal: "\x00"},
+	}
+
+	lexer := New(input)
+
+	for i, test := range tests {
+		tok := lexer.NextToken()
+
+		if tok.Type != test.Type {
+			t.Fatalf("[Test %d] Invalid token type: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+
+		if tok.Literal != test.Literal {
+			t.Fatalf("[Test %d] Invalid token literal: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+	}
+
+	expectedDiagnostics := []string{
+		"3:11: error[E0008]: Invalid escape sequence \"\\q\"",
+		"3:22: error[E0007]: Unterminated string literal",
+	}
+
+	if diagnosticAmount := len(lexer.Diagnostics); diagnosticAmount != len(expectedDiagnostics) {
+		t.Fatalf("[Test] Invalid diagnostic amount: received %d, expected %d", diagnosticAmount, len(expectedDiagnostics))
+	}
+
+	for i, expected := range expectedDiagnostics {
+		if diag := lexer.Diagnostics[i].Error(); diag != expected {
+			t.Errorf("[Test] Invalid diagnostic: received %s, expected %s", diag, expected)
+		}
+	}
+}
+
+func TestStringInterpolation(t *testing.T) {
+	input := "\"Hello ${name}, you have ${len(items)} items\" \"${ {\"a\": \"${b}\"}[\"a\"] }\" \"\\${raw} $5\""
+	tests := []token.Token{
+		{Type: token.STRING_HEAD, Literal: "Hello "},
+		{Type: token.IDENTIFIER, Literal: "name"},
+		{Type: token.STRING_MIDDLE, Literal: ", you have "},
+		{Type: token.IDENTIFIER, Literal: "len"},
+		{Type: token.LPAREN, Literal: "("},
+		{Type: token.IDENTIFIER, Literal: "items"},
+		{Type: token.RPAREN, Literal: ")"},
+		{Type: token.STRING_TAIL, Literal: " items"},
+		{Type: token.STRING_HEAD, Literal: ""},
+		{Type: token.LBRACE, Literal: "{"},
+		{Type: token.STRING, Literal: "a"},
+		{Type: token.COLON, Literal: ":"},
+		{Type: token.STRING_HEAD, Literal: ""},
+		{Type: token.IDENTIFIER, Literal: "b"},
+		{Type: token.STRING_TAIL, Literal: ""},
+		{Type: token.RBRACE, Literal: "}"},
+		{Type: token.LBRACKET, Literal: "["},
+		{Type: token.STRING, Literal: "a"},
+		{Type: token.RBRACKET, Literal: "]"},
+		{Type: token.STRING_TAIL, Literal: ""},
+		{Type: token.STRING, Literal: "${raw} $5"},
+		{Type: token.EOF, Literal: "\x00"},
+	}
+
+	lexer := New(input)
+
+	for i, test := range tests {
+		tok := lexer.NextToken()
+
+		if tok.Type != test.Type {
+			t.Fatalf("[Test %d] Invalid token type: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+
+		if tok.Literal != test.Literal {
+			t.Fatalf("[Test %d] Invalid token literal: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+	}
+}
+
+func TestUnterminatedInterpolation(t *testing.T) {
+	long := "\"${ 1 }" + strings.Repeat("a", 2*READ_SIZE)
+	tests := []struct {
+		input    string
+		expected []token.Token
+	}{
+		{
+			input: "\"${ 1 }",
+			expected: []token.Token{
+				{Type: token.STRING_HEAD, Literal: ""},
+				{Type: token.INT, Literal: "1"},
+				{Type: token.ILLEGAL, Literal: "\"${ 1 }"},
+				{Type: token.EOF, Literal: "\x00"},
+			},
+		},
+		{
+			input: long,
+			expected: []token.Token{
+				{Type: token.STRING_HEAD, Literal: ""},
+				{Type: token.INT, Literal: "1"},
+				{Type: token.ILLEGAL, Literal: token.TokenLiteral(long)},
+				{Type: token.EOF, Literal: "\x00"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		lexer := NewReader(strings.NewReader(test.input))
+
+		for i, expected := range test.expected {
+			tok := lexer.NextToken()
+
+			if tok.Type != expected.Type || tok.Literal != expected.Literal {
+				t.Fatalf("[Test %d] Invalid token: received %q %q, expected %q %q", i, tok.Type, tok.Literal, expected.Type, expected.Literal)
+			}
+		}
+
+		if diagnosticAmount := len(lexer.Diagnostics); diagnosticAmount != 1 {
+			t.Fatalf("[Test] Invalid diagnostic amount: received %d, expected 1", diagnosticAmount)
+		}
+		if code := lexer.Diagnostics[0].Code; code != diagnostic.UNTERMINATED_STRING {
+			t.Errorf("[Test] Invalid diagnostic code: received %s, expected %s", code, diagnostic.UNTERMINATED_STRING)
+		}
+	}
+}
+
+func TestReader(t *testing.T) {
+	inputs := []string{
+		"let five = 5;\nlet add = fn(x, y) { x + y; };\nadd(five, 10) <= 0xFF && !done;",
+		"// leading\nlet x = 5; // trailing\n/* block /* nested */ */ x / 2;",
+		"let café = \"é😀 ${name} \\u{1F600}\"; naïve ≠ `raw`",
+		"\"bad \\q escape\" \"unterminated",
+		"/* unterminated",
+	}
+
+	for _, input := range inputs {
+		stringLexer := New(input)
+		stringLexer.KeepComments()
+		readerLexer := NewReader(iotest.OneByteReader(strings.NewReader(input)))
+		readerLexer.KeepComments()
+
+		for {
+			expected := stringLexer.NextToken()
+			tok := readerLexer.NextToken()
+
+			if !reflect.DeepEqual(tok, expected) {
+				t.Fatalf("[Test] Invalid token: received %+v, expected %+v", tok, expected)
+			}
+
+			if tok.Type == token.EOF {
+				break
+			}
+		}
+
+		if !reflect.DeepEqual(readerLexer.Diagnostics, stringLexer.Diagnostics) {
+			t.Errorf("[Test] Invalid diagnostics: received %v, expected %v", readerLexer.Diagnostics, stringLexer.Diagnostics)
+		}
+	}
+}
+
+func TestReaderError(t *testing.T) {
+	lexer := NewReader(iotest.TimeoutReader(iotest.OneByteReader(strings.NewReader("let x = 5;"))))
+	tests := []token.Token{
+		{Type: token.IDENTIFIER, Literal: "l"},
+		{Type: token.ILLEGAL, Literal: ""},
+		{Type: token.EOF, Literal: "\x00"},
+	}
+
+	for i, test := range tests {
+		tok := lexer.NextToken()
+
+		if tok.Type != test.Type || tok.Literal != test.Literal {
+			t.Fatalf("[Test %d] Invalid token: received %q %q, expected %q %q", i, tok.Type, tok.Literal, test.Type, test.Literal)
+		}
+	}
+
+	expectedDiagnostic := "1:2: error[E0015]: Failed to read input: timeout"
+	if diagnosticAmount := len(lexer.Diagnostics); diagnosticAmount != 1 {
+		t.Fatalf("[Test] Invalid diagnostic amount: received %d, expected 1", diagnosticAmount)
+	}
+	if diag := lexer.Diagnostics[0].Error(); diag != expectedDiagnostic {
+		t.Errorf("[Test] Invalid diagnostic: received %q, expected %q", diag, expectedDiagnostic)
+	}
+
+	if err := lexer.Err(); err != iotest.ErrTimeout {
+		t.Errorf("[Test] Invalid error: received %v, expected %v", err, iotest.ErrTimeout)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	lexer := New("let x = 5;")
+
+	peeked := lexer.Peek(3)
+	expectedTypes := []token.TokenType{token.LET, token.IDENTIFIER, token.ASSIGN}
+	if peekedAmount := len(peeked); peekedAmount != len(expectedTypes) {
+		t.Fatalf("[Test] Invalid peeked token amount: received %d, expected %d", peekedAmount, len(expectedTypes))
+	}
+	for i, expectedType := range expectedTypes {
+		if peeked[i].Type != expectedType {
+			t.Errorf("[Test %d] Invalid peeked token type: received %q, expected %q", i, peeked[i].Type, expectedType)
+		}
+	}
+
+	if tok := lexer.Peek(1)[0]; tok.Type != token.LET {
+		t.Errorf("[Test] Invalid peeked token type: received %q, expected %q", tok.Type, token.LET)
+	}
+
+	expectedTypes = []token.TokenType{token.LET, token.IDENTIFIER, token.ASSIGN, token.INT, token.SEMICOLON, token.EOF}
+	for i, expectedType := range expectedTypes {
+		if i == 3 {
+			if tok := lexer.Peek(2)[1]; tok.Type != token.SEMICOLON {
+				t.Errorf("[Test] Invalid peeked token type: received %q, expected %q", tok.Type, token.SEMICOLON)
+			}
+		}
+
+		if tok := lexer.NextToken(); tok.Type != expectedType {
+			t.Errorf("[Test %d] Invalid token type: received %q, expected %q", i, tok.Type, expectedType)
+		}
+	}
+}
+
+func TestReaderBufferIsBounded(t *testing.T) {
+	line := "let value = \"some text\"; // comment\n"
+	lineAmount := 50000
+	lexer := NewReader(strings.NewReader(strings.Repeat(line, lineAmount)))
+
+	tokenAmount := 0
+	for tok := lexer.NextToken(); tok.Type != token.EOF; tok = lexer.NextToken() {
+		tokenAmount += 1
+	}
+
+	if expectedTokenAmount := 5 * lineAmount; tokenAmount != expectedTokenAmount {
+		t.Errorf("[Test] Invalid token amount: received %d, expected %d", tokenAmount, expectedTokenAmount)
+	}
+
+	if maxCapacity := 4 * READ_SIZE; cap(lexer.buffer) > maxCapacity {
+		t.Errorf("[Test] Invalid buffer capacity: received %d, expected at most %d", cap(lexer.buffer), maxCapacity)
 	}
 }
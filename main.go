@@ -1,10 +1,27 @@
 package main
 
 import (
+	"fmt"
 	"leonardjouve/repl"
 	"os"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := repl.RunFile(os.Args[1], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice == 0 {
+		if err := repl.Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	repl.Start(os.Stdin, os.Stdout)
 }
@@ -3,15 +3,20 @@ package object
 import "leonardjouve/token"
 
 type Environement struct {
-	store map[token.TokenLiteral]Object
-	outer *Environement
+	store     map[token.TokenLiteral]Object
+	constants map[token.TokenLiteral]bool
+	outer     *Environement
+	callDepth *int
 }
 
 func NewEnvironement() *Environement {
 	store := make(map[token.TokenLiteral]Object)
+	constants := make(map[token.TokenLiteral]bool)
 	return &Environement{
-		store: store,
-		outer: nil,
+		store:     store,
+		constants: constants,
+		outer:     nil,
+		callDepth: new(int),
 	}
 }
 
@@ -27,8 +32,45 @@ func (env *Environement) Set(identifier token.TokenLiteral, value Object) {
 	env.store[identifier] = value
 }
 
+func (env *Environement) SetConstant(identifier token.TokenLiteral, value Object) {
+	if env.constants == nil {
+		env.constants = make(map[token.TokenLiteral]bool)
+	}
+	env.store[identifier] = value
+	env.constants[identifier] = true
+}
+
+func (env *Environement) IsConstant(identifier token.TokenLiteral) bool {
+	return env.constants[identifier]
+}
+
+func (env *Environement) Resolve(identifier token.TokenLiteral) (*Environement, bool) {
+	if _, ok := env.store[identifier]; ok {
+		return env, true
+	}
+	if env.outer != nil {
+		return env.outer.Resolve(identifier)
+	}
+	return nil, false
+}
+
+func (env *Environement) CallDepth() int {
+	return *env.callDepth
+}
+
+func (env *Environement) EnterCall() {
+	*env.callDepth += 1
+}
+
+func (env *Environement) LeaveCall() {
+	*env.callDepth -= 1
+}
+
 func NewEnclosedEnvironement(outer *Environement) *Environement {
-	env := NewEnvironement()
-	env.outer = outer
-	return env
+	return &Environement{
+		store:     make(map[token.TokenLiteral]Object),
+		constants: nil,
+		outer:     outer,
+		callDepth: outer.callDepth,
+	}
 }
@@ -0,0 +1,150 @@
+package object
+
+import (
+	"math"
+	"sort"
+)
+
+type Iterable interface {
+	Iterator() Iterator
+}
+
+type Iterator interface {
+	Next() (Object, Object, bool)
+}
+
+type arrayIterator struct {
+	array *Array
+	index int
+}
+
+type hashIterator struct {
+	pairs []HashPair
+	index int
+}
+
+type stringIterator struct {
+	runes []rune
+	index int
+}
+
+type rangeIterator struct {
+	rng     *Range
+	index   int64
+	current int64
+	done    bool
+}
+
+func (array *Array) Iterator() Iterator {
+	return &arrayIterator{
+		array: array,
+		index: 0,
+	}
+}
+func (iterator *arrayIterator) Next() (Object, Object, bool) {
+	if iterator.index >= len(iterator.array.Value) {
+		return nil, nil, false
+	}
+
+	index := &Integer{
+		Value: int64(iterator.index),
+	}
+	value := iterator.array.Value[iterator.index]
+	iterator.index += 1
+
+	return index, value, true
+}
+
+func (hash *Hash) Iterator() Iterator {
+	pairs := make([]HashPair, 0, len(hash.Value))
+	for _, pair := range hash.Value {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return lessObject(pairs[i].Key, pairs[j].Key)
+	})
+
+	return &hashIterator{
+		pairs: pairs,
+		index: 0,
+	}
+}
+func (iterator *hashIterator) Next() (Object, Object, bool) {
+	if iterator.index >= len(iterator.pairs) {
+		return nil, nil, false
+	}
+
+	pair := iterator.pairs[iterator.index]
+	iterator.index += 1
+
+	return pair.Key, pair.Value, true
+}
+
+func (str *String) Iterator() Iterator {
+	return &stringIterator{
+		runes: []rune(str.Value),
+		index: 0,
+	}
+}
+func (iterator *stringIterator) Next() (Object, Object, bool) {
+	if iterator.index >= len(iterator.runes) {
+		return nil, nil, false
+	}
+
+	index := &Integer{
+		Value: int64(iterator.index),
+	}
+	value := &String{
+		Value: string(iterator.runes[iterator.index]),
+	}
+	iterator.index += 1
+
+	return index, value, true
+}
+
+func (rng *Range) Iterator() Iterator {
+	return &rangeIterator{
+		rng:     rng,
+		index:   0,
+		current: rng.Start,
+	}
+}
+func (iterator *rangeIterator) Next() (Object, Object, bool) {
+	if iterator.done || !iterator.rng.contains(iterator.current) {
+		return nil, nil, false
+	}
+
+	index := &Integer{
+		Value: iterator.index,
+	}
+	value := &Integer{
+		Value: iterator.current,
+	}
+	iterator.index += 1
+	if step := iterator.rng.Step; (step > 0 && iterator.current > math.MaxInt64-step) || (step < 0 && iterator.current < math.MinInt64-step) {
+		iterator.done = true
+	} else {
+		iterator.current += step
+	}
+
+	return index, value, true
+}
+
+func lessObject(left Object, right Object) bool {
+	if left.Type() != right.Type() {
+		return left.Type() < right.Type()
+	}
+
+	switch left := left.(type) {
+	case *Integer:
+		return left.Value < right.(*Integer).Value
+	case *Float:
+		return left.Value < right.(*Float).Value
+	case *String:
+		return left.Value < right.(*String).Value
+	case *Boolean:
+		return !left.Value && right.(*Boolean).Value
+	default:
+		return left.Inspect() < right.Inspect()
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"hash/fnv"
 	"leonardjouve/ast"
 	"leonardjouve/token"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -24,6 +26,10 @@ type Integer struct {
 	Value int64
 }
 
+type Float struct {
+	Value float64
+}
+
 type Boolean struct {
 	Value bool
 }
@@ -34,12 +40,41 @@ type Return struct {
 	Value Object
 }
 
+type Break struct{}
+
+type Continue struct{}
+
 type Error struct {
-	Value string
+	Value         string
+	Kind          string
+	Position      token.Position
+	Cause         *Error
+	Thrown        Object
+	Stack         []Frame
+	OmittedFrames int
+}
+
+type Frame struct {
+	Function  string
+	Arguments string
+	Position  token.Position
+}
+
+type Exception struct {
+	Error *Error
+}
+
+type TailCall struct {
+	Function       *Function
+	Arguments      []Object
+	NamedArguments map[token.TokenLiteral]Object
+	Callee         ast.Expression
+	Position       token.Position
 }
 
 type Function struct {
-	Parameters []*ast.Identifier
+	Name       token.TokenLiteral
+	Parameters []ast.Expression
 	Body       *ast.BlockStatement
 	Env        *Environement
 }
@@ -72,6 +107,12 @@ type Hash struct {
 	Value map[HashKey]HashPair
 }
 
+type Range struct {
+	Start int64
+	End   int64
+	Step  int64
+}
+
 type Quote struct {
 	Value ast.Node
 }
@@ -85,16 +126,28 @@ type Macro struct {
 const (
 	NULL     = "NULL"
 	INTEGER  = "INTEGER"
+	FLOAT    = "FLOAT"
 	BOOLEAN  = "BOOLEAN"
 	RETURN   = "RETURN"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
 	ERROR    = "ERROR"
 	FUNCTION = "FUNCTION"
 	STRING   = "STRING"
 	BUILTIN  = "BUILTIN"
 	ARRAY    = "ARRAY"
 	HASH     = "HASH"
+	RANGE    = "RANGE"
 	QUOTE    = "QUOTE"
 	MACRO    = "MACRO"
+
+	EXCEPTION = "EXCEPTION"
+	TAIL_CALL = "TAIL_CALL"
+)
+
+const (
+	ERROR_KIND         = "Error"
+	RUNTIME_ERROR_KIND = "RuntimeError"
 )
 
 func (integer *Integer) Type() ObjectType {
@@ -110,6 +163,23 @@ func (integer *Integer) HashKey() HashKey {
 	}
 }
 
+func (float *Float) Type() ObjectType {
+	return FLOAT
+}
+func (float *Float) Inspect() string {
+	inspect := strconv.FormatFloat(float.Value, 'g', -1, 64)
+	if !strings.ContainsAny(inspect, ".eEnN") {
+		inspect += ".0"
+	}
+	return inspect
+}
+func (float *Float) HashKey() HashKey {
+	return HashKey{
+		Type:  float.Type(),
+		Value: math.Float64bits(float.Value),
+	}
+}
+
 func (boolean *Boolean) Type() ObjectType {
 	return BOOLEAN
 }
@@ -145,13 +215,75 @@ func (ret *Return) Inspect() string {
 	return ret.Value.Inspect()
 }
 
+func (brk *Break) Type() ObjectType {
+	return BREAK
+}
+func (brk *Break) Inspect() string {
+	return "break"
+}
+
+func (cont *Continue) Type() ObjectType {
+	return CONTINUE
+}
+func (cont *Continue) Inspect() string {
+	return "continue"
+}
+
 func (err *Error) Type() ObjectType {
 	return ERROR
 }
 func (err *Error) Inspect() string {
+	if err.Position.IsValid() {
+		return "[Error] " + err.Position.String() + ": " + err.Value
+	}
 	return "[Error] " + err.Value
 }
 
+func (err *Error) Traceback() string {
+	if len(err.Stack) == 0 {
+		return err.Inspect()
+	}
+
+	var out bytes.Buffer
+
+	out.WriteString("Traceback (most recent call last):\n")
+	if err.OmittedFrames > 0 {
+		out.WriteString(fmt.Sprintf("  ... %d more frames\n", err.OmittedFrames))
+	}
+	for i := len(err.Stack) - 1; i >= 0; i-- {
+		out.WriteString("  " + err.Stack[i].String() + "\n")
+	}
+	out.WriteString(err.Inspect())
+
+	return out.String()
+}
+
+func (frame Frame) String() string {
+	call := frame.Function + "(" + frame.Arguments + ")"
+	if !frame.Position.IsValid() {
+		return "in " + call
+	}
+	return frame.Position.String() + ": in " + call
+}
+
+func (exception *Exception) Type() ObjectType {
+	return EXCEPTION
+}
+func (exception *Exception) Inspect() string {
+	kind := exception.Error.Kind
+	if len(kind) == 0 {
+		kind = ERROR_KIND
+	}
+	return kind + ": " + exception.Error.Value
+}
+
+func (tailCall *TailCall) Type() ObjectType {
+	return TAIL_CALL
+}
+func (tailCall *TailCall) Inspect() string {
+	return "tail call"
+}
+
 func (function *Function) Type() ObjectType {
 	return FUNCTION
 }
@@ -168,6 +300,10 @@ func (function *Function) Inspect() string {
 		out.WriteString(string(functionKeyword))
 	}
 
+	if len(function.Name) > 0 {
+		out.WriteString(" " + string(function.Name))
+	}
+
 	out.WriteString("(" + strings.Join(params, ", ") + ") {\n" + function.Body.String() + "\n}")
 
 	return out.String()
@@ -200,11 +336,20 @@ func (array *Array) Type() ObjectType {
 	return ARRAY
 }
 func (array *Array) Inspect() string {
+	return array.inspect(map[Object]bool{})
+}
+func (array *Array) inspect(seen map[Object]bool) string {
+	if seen[array] {
+		return "[...]"
+	}
+	seen[array] = true
+	defer delete(seen, array)
+
 	var out bytes.Buffer
 
 	elements := []string{}
 	for _, element := range array.Value {
-		elements = append(elements, element.Inspect())
+		elements = append(elements, inspectNested(element, seen))
 	}
 
 	out.WriteString("[" + strings.Join(elements, ", ") + "]")
@@ -216,11 +361,20 @@ func (hash *Hash) Type() ObjectType {
 	return HASH
 }
 func (hash *Hash) Inspect() string {
+	return hash.inspect(map[Object]bool{})
+}
+func (hash *Hash) inspect(seen map[Object]bool) string {
+	if seen[hash] {
+		return "{...}"
+	}
+	seen[hash] = true
+	defer delete(seen, hash)
+
 	var out bytes.Buffer
 
 	elements := []string{}
 	for _, value := range hash.Value {
-		elements = append(elements, value.Key.Inspect()+": "+value.Value.Inspect())
+		elements = append(elements, value.Key.Inspect()+": "+inspectNested(value.Value, seen))
 	}
 
 	out.WriteString("{" + strings.Join(elements, ", ") + "}")
@@ -228,6 +382,46 @@ func (hash *Hash) Inspect() string {
 	return out.String()
 }
 
+func inspectNested(obj Object, seen map[Object]bool) string {
+	switch obj := obj.(type) {
+	case *Array:
+		return obj.inspect(seen)
+	case *Hash:
+		return obj.inspect(seen)
+	default:
+		return obj.Inspect()
+	}
+}
+
+func (rng *Range) Type() ObjectType {
+	return RANGE
+}
+func (rng *Range) Inspect() string {
+	return fmt.Sprintf("range(%d, %d, %d)", rng.Start, rng.End, rng.Step)
+}
+func (rng *Range) Length() int64 {
+	var span, step uint64
+	if rng.Step > 0 && rng.Start < rng.End {
+		span, step = uint64(rng.End-rng.Start), uint64(rng.Step)
+	} else if rng.Step < 0 && rng.Start > rng.End {
+		span, step = uint64(rng.Start-rng.End), uint64(-rng.Step)
+	} else {
+		return 0
+	}
+
+	length := (span-1)/step + 1
+	if length > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(length)
+}
+func (rng *Range) contains(value int64) bool {
+	if rng.Step > 0 {
+		return value < rng.End
+	}
+	return value > rng.End
+}
+
 func (quote *Quote) Type() ObjectType {
 	return QUOTE
 }
@@ -1,6 +1,9 @@
 package object
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestStringHashKey(t *testing.T) {
 	tests := [][]*String{
@@ -35,3 +38,187 @@ func TestStringHashKey(t *testing.T) {
 		}
 	}
 }
+
+func TestFloatInspect(t *testing.T) {
+	type FloatInspectTest struct {
+		input    float64
+		expected string
+	}
+	tests := []FloatInspectTest{
+		{
+			input:    1.5,
+			expected: "1.5",
+		},
+		{
+			input:    2,
+			expected: "2.0",
+		},
+		{
+			input:    -0.001,
+			expected: "-0.001",
+		},
+		{
+			input:    1e21,
+			expected: "1e+21",
+		},
+		{
+			input:    math.Inf(1),
+			expected: "+Inf",
+		},
+	}
+
+	for _, test := range tests {
+		float := &Float{
+			Value: test.input,
+		}
+
+		if inspect := float.Inspect(); inspect != test.expected {
+			t.Errorf("[Test] Invalid float inspect: received %s, expected %s", inspect, test.expected)
+		}
+	}
+}
+
+func TestCyclicInspect(t *testing.T) {
+	array := &Array{
+		Value: []Object{&Integer{Value: 1}},
+	}
+	array.Value = append(array.Value, array)
+
+	key := &String{Value: "self"}
+	hash := &Hash{
+		Value: map[HashKey]HashPair{},
+	}
+	hash.Value[key.HashKey()] = HashPair{Key: key, Value: hash}
+
+	shared := &Array{
+		Value: []Object{array, array},
+	}
+
+	type CyclicInspectTest struct {
+		input    Object
+		expected string
+	}
+	tests := []CyclicInspectTest{
+		{
+			input:    array,
+			expected: "[1, [...]]",
+		},
+		{
+			input:    hash,
+			expected: "{self: {...}}",
+		},
+		{
+			input:    shared,
+			expected: "[[1, [...]], [1, [...]]]",
+		},
+	}
+
+	for _, test := range tests {
+		if inspect := test.input.Inspect(); inspect != test.expected {
+			t.Errorf("[Test] Invalid cyclic inspect: received %s, expected %s", inspect, test.expected)
+		}
+	}
+}
+
+func TestRangeIterator(t *testing.T) {
+	type RangeIteratorTest struct {
+		input    *Range
+		expected []int64
+	}
+	tests := []RangeIteratorTest{
+		{
+			input:    &Range{Start: 0, End: 5, Step: 1},
+			expected: []int64{0, 1, 2, 3, 4},
+		},
+		{
+			input:    &Range{Start: 1, End: 10, Step: 4},
+			expected: []int64{1, 5, 9},
+		},
+		{
+			input:    &Range{Start: 5, End: 0, Step: -2},
+			expected: []int64{5, 3, 1},
+		},
+		{
+			input:    &Range{Start: 5, End: 0, Step: 1},
+			expected: []int64{},
+		},
+		{
+			input:    &Range{Start: math.MaxInt64 - 7, End: math.MaxInt64, Step: 5},
+			expected: []int64{math.MaxInt64 - 7, math.MaxInt64 - 2},
+		},
+		{
+			input:    &Range{Start: math.MinInt64 + 7, End: math.MinInt64, Step: -5},
+			expected: []int64{math.MinInt64 + 7, math.MinInt64 + 2},
+		},
+		{
+			input:    &Range{Start: math.MinInt64, End: math.MinInt64 + 3, Step: math.MaxInt64},
+			expected: []int64{math.MinInt64},
+		},
+	}
+
+	for _, test := range tests {
+		if length := test.input.Length(); length != int64(len(test.expected)) {
+			t.Errorf("[Test] Invalid range length: received %d, expected %d", length, len(test.expected))
+		}
+
+		values := []int64{}
+		iterator := test.input.Iterator()
+		for {
+			key, value, ok := iterator.Next()
+			if !ok {
+				break
+			}
+
+			if index := key.(*Integer).Value; index != int64(len(values)) {
+				t.Errorf("[Test] Invalid range index: received %d, expected %d", index, len(values))
+			}
+			values = append(values, value.(*Integer).Value)
+		}
+
+		if len(values) != len(test.expected) {
+			t.Errorf("[Test] Invalid range values: received %v, expected %v", values, test.expected)
+			continue
+		}
+		for i, expectedValue := range test.expected {
+			if values[i] != expectedValue {
+				t.Errorf("[Test] Invalid range value: received %d, expected %d", values[i], expectedValue)
+			}
+		}
+	}
+}
+
+func TestHashIteratorOrder(t *testing.T) {
+	keys := []Hashable{
+		&String{Value: "b"},
+		&Integer{Value: 10},
+		&String{Value: "a"},
+		&Integer{Value: -1},
+		&Boolean{Value: true},
+		&Boolean{Value: false},
+	}
+	hash := &Hash{
+		Value: map[HashKey]HashPair{},
+	}
+	for _, key := range keys {
+		hash.Value[key.HashKey()] = HashPair{
+			Key:   key.(Object),
+			Value: &Null{},
+		}
+	}
+
+	expected := []string{"false", "true", "-1", "10", "a", "b"}
+	iterator := hash.Iterator()
+	for i, expectedKey := range expected {
+		key, _, ok := iterator.Next()
+		if !ok {
+			t.Fatalf("[Test] Invalid hash iterator length: received %d, expected %d", i, len(expected))
+		}
+		if key.Inspect() != expectedKey {
+			t.Errorf("[Test] Invalid hash iterator key: received %s, expected %s", key.Inspect(), expectedKey)
+		}
+	}
+
+	if _, _, ok := iterator.Next(); ok {
+		t.Errorf("[Test] Invalid hash iterator end: received %t, expected %t", ok, false)
+	}
+}
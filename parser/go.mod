@@ -6,10 +6,13 @@ replace leonardjouve/lexer => ../lexer
 
 replace leonardjouve/ast => ../ast
 
+replace leonardjouve/diagnostic => ../diagnostic
+
 go 1.20
 
 require (
 	leonardjouve/ast v0.0.0-00010101000000-000000000000
+	leonardjouve/diagnostic v0.0.0-00010101000000-000000000000
 	leonardjouve/lexer v0.0.0-00010101000000-000000000000
 	leonardjouve/token v0.0.0-00010101000000-000000000000
 )
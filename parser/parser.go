@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"leonardjouve/ast"
+	"leonardjouve/diagnostic"
 	"leonardjouve/lexer"
 	"leonardjouve/token"
 	"strconv"
@@ -12,7 +14,11 @@ type Parser struct {
 	lex           *lexer.Lexer
 	tok           token.Token
 	nextTok       token.Token
-	Errors        []string
+	Diagnostics   []*diagnostic.Diagnostic
+	panicking     bool
+	loopDepth     int
+	braceDepth    int
+	arrowDisabled bool
 	prefixParsers map[token.TokenType]prefixParser
 	infixParsers  map[token.TokenType]infixParser
 }
@@ -25,8 +31,18 @@ type (
 const (
 	_ int = iota
 	LOWEST
+	ASSIGNMENT
+	TERNARY
+	PIPELINE
+	NULLISH
+	LOGICAL_OR
+	LOGICAL_AND
+	BITWISE_OR
+	BITWISE_XOR
+	BITWISE_AND
 	EQUALS
 	LOWERGREATER
+	SHIFT
 	SUM
 	PRODUCT
 	PREFIX
@@ -35,22 +51,41 @@ const (
 )
 
 var precedence = map[token.TokenType]int{
-	token.EQUAL:     EQUALS,
-	token.NOT_EQUAL: EQUALS,
-	token.LR:        LOWERGREATER,
-	token.GR:        LOWERGREATER,
-	token.PLUS:      SUM,
-	token.MINUS:     SUM,
-	token.ASTERISX:  PRODUCT,
-	token.SLASH:     PRODUCT,
-	token.LPAREN:    CALL,
-	token.LBRACKET:  INDEX,
+	token.ASSIGN:          ASSIGNMENT,
+	token.PLUS_ASSIGN:     ASSIGNMENT,
+	token.MINUS_ASSIGN:    ASSIGNMENT,
+	token.ASTERISX_ASSIGN: ASSIGNMENT,
+	token.SLASH_ASSIGN:    ASSIGNMENT,
+	token.QUESTION:        TERNARY,
+	token.PIPELINE:        PIPELINE,
+	token.NULLISH:         NULLISH,
+	token.OR:              LOGICAL_OR,
+	token.AND:             LOGICAL_AND,
+	token.BITWISE_OR:      BITWISE_OR,
+	token.BITWISE_XOR:     BITWISE_XOR,
+	token.BITWISE_AND:     BITWISE_AND,
+	token.EQUAL:           EQUALS,
+	token.NOT_EQUAL:       EQUALS,
+	token.LR:              LOWERGREATER,
+	token.GR:              LOWERGREATER,
+	token.LR_EQUAL:        LOWERGREATER,
+	token.GR_EQUAL:        LOWERGREATER,
+	token.LEFT_SHIFT:      SHIFT,
+	token.RIGHT_SHIFT:     SHIFT,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.ASTERISX:        PRODUCT,
+	token.SLASH:           PRODUCT,
+	token.MODULO:          PRODUCT,
+	token.LPAREN:          CALL,
+	token.LBRACKET:        INDEX,
+	token.OPTIONAL_INDEX:  INDEX,
 }
 
 func New(lex *lexer.Lexer) *Parser {
 	parser := &Parser{
-		lex:    lex,
-		Errors: []string{},
+		lex:         lex,
+		Diagnostics: []*diagnostic.Diagnostic{},
 	}
 	parser.nextToken()
 	parser.nextToken()
@@ -63,18 +98,74 @@ func New(lex *lexer.Lexer) *Parser {
 func (parser *Parser) nextToken() {
 	parser.tok = parser.nextTok
 	parser.nextTok = parser.lex.NextToken()
+
+	switch parser.tok.Type {
+	case token.LBRACE:
+		parser.braceDepth += 1
+	case token.RBRACE:
+		if parser.braceDepth > 0 {
+			parser.braceDepth -= 1
+		}
+	}
 }
 
-func (parser *Parser) addError(err string) {
-	parser.Errors = append(parser.Errors, err)
+func (parser *Parser) HasErrors() bool {
+	for _, diag := range parser.Diagnostics {
+		if diag.Severity == diagnostic.ERROR {
+			return true
+		}
+	}
+	return false
 }
 
-func (parser *Parser) addInvalidNextTokenTypeError(received token.Token, expected token.TokenType) {
-	parser.addError(fmt.Sprintf("[Error] Invalid next token type: received %s %s, expected %s", received.Type, received.Literal, expected))
+func (parser *Parser) addDiagnostic(diag *diagnostic.Diagnostic) {
+	if diag.Severity == diagnostic.ERROR {
+		if parser.panicking {
+			return
+		}
+		parser.panicking = true
+	}
+	parser.Diagnostics = append(parser.Diagnostics, diag)
+}
+
+func (parser *Parser) addError(code diagnostic.Code, span token.Span, message string) *diagnostic.Diagnostic {
+	diag := &diagnostic.Diagnostic{
+		Severity: diagnostic.ERROR,
+		Code:     code,
+		Span:     span,
+		Message:  message,
+	}
+	parser.addDiagnostic(diag)
+
+	return diag
+}
+
+func (parser *Parser) addInvalidNextTokenTypeError(received token.Token, expected token.TokenType) *diagnostic.Diagnostic {
+	diag := parser.addError(diagnostic.INVALID_NEXT_TOKEN, received.Span, fmt.Sprintf("Invalid next token type: received %s %s, expected %s", received.Type, received.Literal, expected))
+	diag.Expected = []token.TokenType{expected}
+	diag.Received = &received
+
+	literal, ok := token.GetSymbolFromType(expected)
+	if !ok {
+		literal, ok = token.GetKeywordFromType(expected)
+	}
+	if ok {
+		diag.Fix = &diagnostic.Fix{
+			Message: fmt.Sprintf("insert %q", literal),
+			Span: token.Span{
+				Start: received.Span.Start,
+				End:   received.Span.Start,
+			},
+			Replacement: string(literal),
+		}
+	}
+
+	return diag
 }
 
-func (parser *Parser) addInvalidPrefixError(tokenType token.TokenType) {
-	parser.addError(fmt.Sprintf("[Error] Invalid prefix for %s", tokenType))
+func (parser *Parser) addInvalidPrefixError(received token.Token) {
+	diag := parser.addError(diagnostic.INVALID_PREFIX, received.Span, fmt.Sprintf("Invalid prefix for %s", received.Type))
+	diag.Received = &received
 }
 
 func (parser *Parser) ParseProgram() *ast.Program {
@@ -83,9 +174,12 @@ func (parser *Parser) ParseProgram() *ast.Program {
 	}
 
 	for parser.tok.Type != token.EOF {
+		braceDepth := parser.statementBraceDepth()
 		statement := parser.parseStatement()
 		if statement != nil {
 			program.Statements = append(program.Statements, statement)
+		} else {
+			parser.synchronize(braceDepth)
 		}
 		parser.nextToken()
 	}
@@ -93,36 +187,151 @@ func (parser *Parser) ParseProgram() *ast.Program {
 	return program
 }
 
+func (parser *Parser) statementBraceDepth() int {
+	if parser.tok.Type == token.LBRACE {
+		return parser.braceDepth - 1
+	}
+	return parser.braceDepth
+}
+
+func (parser *Parser) synchronize(braceDepth int) bool {
+	parser.panicking = false
+
+	depth := 0
+	for parser.tok.Type != token.EOF {
+		if parser.braceDepth < braceDepth {
+			return true
+		}
+
+		switch parser.tok.Type {
+		case token.LPAREN, token.LBRACKET:
+			depth += 1
+		case token.RPAREN, token.RBRACKET:
+			if depth > 0 {
+				depth -= 1
+			}
+		case token.RBRACE:
+			if parser.braceDepth == braceDepth {
+				if parser.nextTok.Type == token.SEMICOLON {
+					parser.nextToken()
+				}
+				return false
+			}
+		case token.SEMICOLON:
+			if depth == 0 && parser.braceDepth == braceDepth {
+				return false
+			}
+		}
+
+		if nextType := parser.nextTok.Type; depth == 0 && parser.braceDepth == braceDepth && isStatementBoundary(nextType) {
+			return false
+		}
+
+		parser.nextToken()
+	}
+
+	return false
+}
+
+func isStatementBoundary(tokenType token.TokenType) bool {
+	switch tokenType {
+	case token.LET, token.CONST, token.RETURN, token.WHILE, token.FOR, token.TRY, token.THROW, token.BREAK, token.CONTINUE, token.RBRACE:
+		return true
+	default:
+		return false
+	}
+}
+
+func (parser *Parser) synchronizeList(end token.TokenType) bool {
+	depth := 0
+	for parser.tok.Type != token.EOF {
+		if depth == 0 {
+			if parser.tok.Type == token.COMMA || parser.tok.Type == end {
+				break
+			}
+			if parser.nextTok.Type == token.COMMA || parser.nextTok.Type == end {
+				break
+			}
+			if parser.tok.Type == token.SEMICOLON {
+				return false
+			}
+		}
+
+		switch parser.tok.Type {
+		case token.LPAREN, token.LBRACKET, token.LBRACE:
+			depth += 1
+		case token.RPAREN, token.RBRACKET, token.RBRACE:
+			depth -= 1
+			if depth < 0 {
+				return false
+			}
+		}
+
+		parser.nextToken()
+	}
+
+	if parser.tok.Type == token.EOF {
+		return false
+	}
+	parser.panicking = false
+
+	return true
+}
+
 func (parser *Parser) addPrefixParsers() {
 	parser.prefixParsers = map[token.TokenType]prefixParser{
-		token.IDENTIFIER: parser.parseIdentifier,
-		token.INT:        parser.parseIntegerLiteral,
-		token.MINUS:      parser.parsePrefixExpression,
-		token.BANG:       parser.parsePrefixExpression,
-		token.TRUE:       parser.parseBoolean,
-		token.FALSE:      parser.parseBoolean,
-		token.LPAREN:     parser.parseGroupedExpression,
-		token.IF:         parser.parseIfExpression,
-		token.FUNCTION:   parser.parseFunctionLiteral,
-		token.STRING:     parser.parseStringLiteral,
-		token.LBRACKET:   parser.parseArrayLiteral,
-		token.LBRACE:     parser.parseHashLiteral,
-		token.MACRO:      parser.parseMacroLiteral,
+		token.IDENTIFIER:  parser.parseIdentifier,
+		token.INT:         parser.parseIntegerLiteral,
+		token.FLOAT:       parser.parseFloatLiteral,
+		token.MINUS:       parser.parsePrefixExpression,
+		token.ELLIPSIS:    parser.parseSpreadExpression,
+		token.BANG:        parser.parsePrefixExpression,
+		token.TRUE:        parser.parseBoolean,
+		token.FALSE:       parser.parseBoolean,
+		token.LPAREN:      parser.parseGroupedExpression,
+		token.IF:          parser.parseIfExpression,
+		token.MATCH:       parser.parseMatchExpression,
+		token.FUNCTION:    parser.parseFunctionLiteral,
+		token.STRING:      parser.parseStringLiteral,
+		token.STRING_HEAD: parser.parseInterpolatedString,
+		token.LBRACKET:    parser.parseArrayLiteral,
+		token.LBRACE:      parser.parseHashLiteral,
+		token.MACRO:       parser.parseMacroLiteral,
+		token.ILLEGAL:     parser.parseIllegal,
 	}
 }
 
 func (parser *Parser) addInfixParsers() {
 	parser.infixParsers = map[token.TokenType]infixParser{
-		token.EQUAL:     parser.parseInfixExpression,
-		token.NOT_EQUAL: parser.parseInfixExpression,
-		token.LR:        parser.parseInfixExpression,
-		token.GR:        parser.parseInfixExpression,
-		token.PLUS:      parser.parseInfixExpression,
-		token.MINUS:     parser.parseInfixExpression,
-		token.ASTERISX:  parser.parseInfixExpression,
-		token.SLASH:     parser.parseInfixExpression,
-		token.LPAREN:    parser.parseCallExpression,
-		token.LBRACKET:  parser.parseIndexExpression,
+		token.ASSIGN:          parser.parseAssignExpression,
+		token.PLUS_ASSIGN:     parser.parseAssignExpression,
+		token.MINUS_ASSIGN:    parser.parseAssignExpression,
+		token.ASTERISX_ASSIGN: parser.parseAssignExpression,
+		token.SLASH_ASSIGN:    parser.parseAssignExpression,
+		token.QUESTION:        parser.parseConditionalExpression,
+		token.PIPELINE:        parser.parsePipelineExpression,
+		token.NULLISH:         parser.parseInfixExpression,
+		token.OR:              parser.parseInfixExpression,
+		token.AND:             parser.parseInfixExpression,
+		token.BITWISE_OR:      parser.parseInfixExpression,
+		token.BITWISE_XOR:     parser.parseInfixExpression,
+		token.BITWISE_AND:     parser.parseInfixExpression,
+		token.EQUAL:           parser.parseInfixExpression,
+		token.NOT_EQUAL:       parser.parseInfixExpression,
+		token.LR:              parser.parseInfixExpression,
+		token.GR:              parser.parseInfixExpression,
+		token.LR_EQUAL:        parser.parseInfixExpression,
+		token.GR_EQUAL:        parser.parseInfixExpression,
+		token.LEFT_SHIFT:      parser.parseInfixExpression,
+		token.RIGHT_SHIFT:     parser.parseInfixExpression,
+		token.PLUS:            parser.parseInfixExpression,
+		token.MINUS:           parser.parseInfixExpression,
+		token.ASTERISX:        parser.parseInfixExpression,
+		token.SLASH:           parser.parseInfixExpression,
+		token.MODULO:          parser.parseInfixExpression,
+		token.LPAREN:          parser.parseCallExpression,
+		token.LBRACKET:        parser.parseIndexExpression,
+		token.OPTIONAL_INDEX:  parser.parseIndexExpression,
 	}
 }
 
@@ -155,6 +364,25 @@ func (parser *Parser) parseStatement() ast.Statement {
 	switch parser.tok.Type {
 	case token.LET:
 		return parser.parseLetStatement()
+	case token.CONST:
+		return parser.parseConstStatement()
+	case token.WHILE:
+		return parser.parseWhileStatement()
+	case token.FOR:
+		return parser.parseForStatement()
+	case token.FUNCTION:
+		if parser.nextTok.Type != token.IDENTIFIER {
+			return parser.parseExpressionStatement()
+		}
+		return parser.parseFunctionStatement()
+	case token.TRY:
+		return parser.parseTryStatement()
+	case token.THROW:
+		return parser.parseThrowStatement()
+	case token.BREAK:
+		return parser.parseBreakStatement()
+	case token.CONTINUE:
+		return parser.parseContinueStatement()
 	case token.RETURN:
 		return parser.parseReturnStatement()
 	default:
@@ -162,16 +390,57 @@ func (parser *Parser) parseStatement() ast.Statement {
 	}
 }
 
-func (parser *Parser) parseLetStatement() *ast.LetStatement {
+func (parser *Parser) parseLetStatement() ast.Statement {
 	letStatement := &ast.LetStatement{
 		Token: parser.tok,
 	}
 
+	switch parser.nextTok.Type {
+	case token.LBRACKET, token.LBRACE:
+		parser.nextToken()
+
+		letStatement.Name = parser.parseExpression(ASSIGNMENT)
+		if letStatement.Name == nil || !parser.validatePattern(letStatement.Name) {
+			return nil
+		}
+	default:
+		if !parser.expectNextTokenType(token.IDENTIFIER) {
+			return nil
+		}
+
+		letStatement.Name = &ast.Identifier{
+			Token: parser.tok,
+			Value: parser.tok.Literal,
+		}
+	}
+
+	if !parser.expectNextTokenType(token.ASSIGN) {
+		return nil
+	}
+
+	parser.nextToken()
+	letStatement.Value = parser.parseExpression(LOWEST)
+	if letStatement.Value == nil {
+		return nil
+	}
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return letStatement
+}
+
+func (parser *Parser) parseConstStatement() ast.Statement {
+	constStatement := &ast.ConstStatement{
+		Token: parser.tok,
+	}
+
 	if !parser.expectNextTokenType(token.IDENTIFIER) {
 		return nil
 	}
 
-	letStatement.Name = &ast.Identifier{
+	constStatement.Name = &ast.Identifier{
 		Token: parser.tok,
 		Value: parser.tok.Literal,
 	}
@@ -181,22 +450,328 @@ func (parser *Parser) parseLetStatement() *ast.LetStatement {
 	}
 
 	parser.nextToken()
-	letStatement.Value = parser.parseExpression(LOWEST)
+	constStatement.Value = parser.parseExpression(LOWEST)
+	if constStatement.Value == nil {
+		return nil
+	}
 
 	if parser.nextTok.Type == token.SEMICOLON {
 		parser.nextToken()
 	}
 
-	return letStatement
+	return constStatement
+}
+
+func (parser *Parser) parseWhileStatement() ast.Statement {
+	whileStatement := &ast.WhileStatement{
+		Token: parser.tok,
+	}
+
+	if !parser.expectNextTokenType(token.LPAREN) {
+		return nil
+	}
+
+	parser.nextToken()
+
+	whileStatement.Condition = parser.parseExpression(LOWEST)
+	if whileStatement.Condition == nil {
+		return nil
+	}
+
+	if !parser.expectNextTokenType(token.RPAREN) {
+		return nil
+	}
+
+	whileStatement.Body = parser.parseLoopBody()
+	if whileStatement.Body == nil {
+		return nil
+	}
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return whileStatement
+}
+
+func (parser *Parser) parseForStatement() ast.Statement {
+	forStatement := &ast.ForStatement{
+		Token: parser.tok,
+	}
+
+	if !parser.expectNextTokenType(token.LPAREN) {
+		return nil
+	}
+
+	parser.nextToken()
+
+	if parser.tok.Type == token.IDENTIFIER && (parser.nextTok.Type == token.IN || parser.nextTok.Type == token.COMMA) {
+		return parser.parseForInStatement(forStatement.Token)
+	}
+
+	if parser.tok.Type != token.SEMICOLON {
+		switch parser.tok.Type {
+		case token.LET, token.CONST:
+			forStatement.Init = parser.parseStatement()
+		default:
+			forStatement.Init = parser.parseExpressionStatement()
+		}
+		if forStatement.Init == nil {
+			return nil
+		}
+
+		if parser.tok.Type != token.SEMICOLON {
+			diag := parser.addInvalidNextTokenTypeError(parser.nextTok, token.SEMICOLON)
+			diag.Span = forStatement.Init.Span()
+			return nil
+		}
+	}
+
+	if parser.nextTok.Type != token.SEMICOLON {
+		parser.nextToken()
+
+		forStatement.Condition = parser.parseExpression(LOWEST)
+		if forStatement.Condition == nil {
+			return nil
+		}
+	}
+
+	if !parser.expectNextTokenType(token.SEMICOLON) {
+		return nil
+	}
+
+	if parser.nextTok.Type != token.RPAREN {
+		parser.nextToken()
+
+		forStatement.Update = parser.parseExpression(LOWEST)
+		if forStatement.Update == nil {
+			return nil
+		}
+	}
+
+	if !parser.expectNextTokenType(token.RPAREN) {
+		return nil
+	}
+
+	forStatement.Body = parser.parseLoopBody()
+	if forStatement.Body == nil {
+		return nil
+	}
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return forStatement
+}
+
+func (parser *Parser) parseForInStatement(tok token.Token) ast.Statement {
+	forInStatement := &ast.ForInStatement{
+		Token: tok,
+	}
+
+	forInStatement.Value = &ast.Identifier{
+		Token: parser.tok,
+		Value: parser.tok.Literal,
+	}
+
+	if parser.nextTok.Type == token.COMMA {
+		parser.nextToken()
+		if !parser.expectNextTokenType(token.IDENTIFIER) {
+			return nil
+		}
+
+		forInStatement.Key = forInStatement.Value
+		forInStatement.Value = &ast.Identifier{
+			Token: parser.tok,
+			Value: parser.tok.Literal,
+		}
+	}
+
+	if !parser.expectNextTokenType(token.IN) {
+		return nil
+	}
+
+	parser.nextToken()
+	forInStatement.Iterable = parser.parseExpression(LOWEST)
+	if forInStatement.Iterable == nil {
+		return nil
+	}
+
+	if !parser.expectNextTokenType(token.RPAREN) {
+		return nil
+	}
+
+	forInStatement.Body = parser.parseLoopBody()
+	if forInStatement.Body == nil {
+		return nil
+	}
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return forInStatement
+}
+
+func (parser *Parser) parseLoopBody() *ast.BlockStatement {
+	if !parser.expectNextTokenType(token.LBRACE) {
+		return nil
+	}
+
+	parser.loopDepth += 1
+	body := parser.parseBlockStatement()
+	parser.loopDepth -= 1
+
+	return body
+}
+
+func (parser *Parser) parseFunctionStatement() ast.Statement {
+	functionStatement := &ast.FunctionStatement{
+		Token: parser.tok,
+	}
+
+	functionLiteral, ok := parser.parseFunctionLiteral().(*ast.FunctionLiteral)
+	if !ok {
+		return nil
+	}
+	functionStatement.Value = functionLiteral
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return functionStatement
+}
+
+func (parser *Parser) parseTryStatement() ast.Statement {
+	tryStatement := &ast.TryStatement{
+		Token: parser.tok,
+	}
+
+	if !parser.expectNextTokenType(token.LBRACE) {
+		return nil
+	}
+
+	tryStatement.Block = parser.parseBlockStatement()
+	if tryStatement.Block == nil {
+		return nil
+	}
+
+	if parser.nextTok.Type == token.CATCH {
+		parser.nextToken()
+
+		if parser.nextTok.Type == token.LPAREN {
+			parser.nextToken()
+
+			if !parser.expectNextTokenType(token.IDENTIFIER) {
+				return nil
+			}
+
+			tryStatement.Parameter = &ast.Identifier{
+				Token: parser.tok,
+				Value: parser.tok.Literal,
+			}
+
+			if !parser.expectNextTokenType(token.RPAREN) {
+				return nil
+			}
+		}
+
+		if !parser.expectNextTokenType(token.LBRACE) {
+			return nil
+		}
+
+		tryStatement.Catch = parser.parseBlockStatement()
+		if tryStatement.Catch == nil {
+			return nil
+		}
+	}
+
+	if parser.nextTok.Type == token.FINALLY || tryStatement.Catch == nil {
+		if !parser.expectNextTokenType(token.FINALLY) {
+			return nil
+		}
+
+		if !parser.expectNextTokenType(token.LBRACE) {
+			return nil
+		}
+
+		tryStatement.Finally = parser.parseBlockStatement()
+		if tryStatement.Finally == nil {
+			return nil
+		}
+	}
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return tryStatement
+}
+
+func (parser *Parser) parseThrowStatement() ast.Statement {
+	throwStatement := &ast.ThrowStatement{
+		Token: parser.tok,
+	}
+
+	parser.nextToken()
+	throwStatement.Value = parser.parseExpression(LOWEST)
+	if throwStatement.Value == nil {
+		return nil
+	}
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return throwStatement
+}
+
+func (parser *Parser) parseBreakStatement() ast.Statement {
+	breakStatement := &ast.BreakStatement{
+		Token: parser.tok,
+	}
+
+	if parser.loopDepth == 0 {
+		parser.addError(diagnostic.OUTSIDE_LOOP, parser.tok.Span, fmt.Sprintf("%s outside of loop", parser.tok.Literal))
+		return nil
+	}
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return breakStatement
+}
+
+func (parser *Parser) parseContinueStatement() ast.Statement {
+	continueStatement := &ast.ContinueStatement{
+		Token: parser.tok,
+	}
+
+	if parser.loopDepth == 0 {
+		parser.addError(diagnostic.OUTSIDE_LOOP, parser.tok.Span, fmt.Sprintf("%s outside of loop", parser.tok.Literal))
+		return nil
+	}
+
+	if parser.nextTok.Type == token.SEMICOLON {
+		parser.nextToken()
+	}
+
+	return continueStatement
 }
 
-func (parser *Parser) parseReturnStatement() *ast.ReturnStatement {
+func (parser *Parser) parseReturnStatement() ast.Statement {
 	returnStatement := &ast.ReturnStatement{
 		Token: parser.tok,
 	}
 
 	parser.nextToken()
 	returnStatement.Value = parser.parseExpression(LOWEST)
+	if returnStatement.Value == nil {
+		return nil
+	}
 
 	if parser.nextTok.Type == token.SEMICOLON {
 		parser.nextToken()
@@ -205,7 +780,7 @@ func (parser *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return returnStatement
 }
 
-func (parser *Parser) parseExpressionStatement() *ast.ExpressionStatement {
+func (parser *Parser) parseExpressionStatement() ast.Statement {
 	defer untrace(trace("parseExpressionStatement"))
 	expressionStatement := &ast.ExpressionStatement{
 		Token: parser.tok,
@@ -228,13 +803,13 @@ func (parser *Parser) parseExpression(prec int) ast.Expression {
 	defer untrace(trace("parseExpression"))
 	prefix, ok := parser.prefixParsers[parser.tok.Type]
 	if !ok {
-		parser.addInvalidPrefixError(parser.tok.Type)
+		parser.addInvalidPrefixError(parser.tok)
 		return nil
 	}
 
 	left := prefix()
 
-	for parser.nextTok.Type != token.SEMICOLON && prec < parser.getNextPrecedence() {
+	for left != nil && parser.nextTok.Type != token.SEMICOLON && prec < parser.getNextPrecedence() {
 		infix, ok := parser.infixParsers[parser.nextTok.Type]
 		if !ok {
 			return left
@@ -248,11 +823,29 @@ func (parser *Parser) parseExpression(prec int) ast.Expression {
 	return left
 }
 
+func (parser *Parser) parseIllegal() ast.Expression {
+	for _, diag := range parser.lex.Diagnostics {
+		if offset := diag.Span.Start.Offset; offset >= parser.tok.Span.Start.Offset && offset <= parser.tok.Span.End.Offset {
+			parser.addDiagnostic(diag)
+			return nil
+		}
+	}
+
+	parser.addInvalidPrefixError(parser.tok)
+	return nil
+}
+
 func (parser *Parser) parseIdentifier() ast.Expression {
-	return &ast.Identifier{
+	identifier := &ast.Identifier{
 		Token: parser.tok,
 		Value: parser.tok.Literal,
 	}
+
+	if parser.nextTok.Type == token.ARROW && !parser.arrowDisabled {
+		return parser.parseArrowFunction(parser.tok, []ast.Expression{identifier})
+	}
+
+	return identifier
 }
 
 func (parser *Parser) parseIntegerLiteral() ast.Expression {
@@ -263,8 +856,14 @@ func (parser *Parser) parseIntegerLiteral() ast.Expression {
 
 	value, err := strconv.ParseInt(string(parser.tok.Literal), 0, 64)
 	if err != nil {
-		err := fmt.Sprintf("[Error] Invalid token literal. Could not parse %s as int", parser.tok.Literal)
-		parser.addError(err)
+		received := parser.tok
+		var diag *diagnostic.Diagnostic
+		if errors.Is(err, strconv.ErrRange) {
+			diag = parser.addError(diagnostic.INTEGER_OVERFLOW, received.Span, fmt.Sprintf("Integer literal %s overflows int64", received.Literal))
+		} else {
+			diag = parser.addError(diagnostic.INVALID_INTEGER, received.Span, fmt.Sprintf("Invalid token literal. Could not parse %s as int", received.Literal))
+		}
+		diag.Received = &received
 		return nil
 	}
 	integer.Value = value
@@ -272,6 +871,23 @@ func (parser *Parser) parseIntegerLiteral() ast.Expression {
 	return integer
 }
 
+func (parser *Parser) parseFloatLiteral() ast.Expression {
+	float := &ast.FloatLiteral{
+		Token: parser.tok,
+	}
+
+	value, err := strconv.ParseFloat(string(parser.tok.Literal), 64)
+	if err != nil {
+		received := parser.tok
+		diag := parser.addError(diagnostic.INVALID_FLOAT, received.Span, fmt.Sprintf("Invalid token literal. Could not parse %s as float", received.Literal))
+		diag.Received = &received
+		return nil
+	}
+	float.Value = value
+
+	return float
+}
+
 func (parser *Parser) parsePrefixExpression() ast.Expression {
 	defer untrace(trace("parsePrefixExpression"))
 	prefixExpression := &ast.PrefixExpression{
@@ -282,90 +898,376 @@ func (parser *Parser) parsePrefixExpression() ast.Expression {
 	parser.nextToken()
 
 	prefixExpression.Right = parser.parseExpression(PREFIX)
+	if prefixExpression.Right == nil {
+		return nil
+	}
 
 	return prefixExpression
 }
 
-func (parser *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
-	defer untrace(trace("parseInfixExpression"))
-	infixExpression := &ast.InfixExpression{
-		Token:    parser.tok,
-		Operator: string(parser.tok.Literal),
-		Left:     left,
+func (parser *Parser) parseSpreadExpression() ast.Expression {
+	spreadExpression := &ast.SpreadExpression{
+		Token: parser.tok,
+	}
+
+	parser.nextToken()
+
+	spreadExpression.Value = parser.parseExpression(PREFIX)
+	if spreadExpression.Value == nil {
+		return nil
+	}
+
+	return spreadExpression
+}
+
+func (parser *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
+	defer untrace(trace("parseInfixExpression"))
+	infixExpression := &ast.InfixExpression{
+		Token:    parser.tok,
+		Operator: string(parser.tok.Literal),
+		Left:     left,
+	}
+
+	prec := parser.getPrecedence()
+	parser.nextToken()
+	infixExpression.Right = parser.parseExpression(prec)
+	if infixExpression.Right == nil {
+		return nil
+	}
+
+	return infixExpression
+}
+
+func (parser *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
+	assignExpression := &ast.AssignExpression{
+		Token:    parser.tok,
+		Operator: string(parser.tok.Literal),
+		Target:   target,
+	}
+
+	switch target := target.(type) {
+	case *ast.Identifier:
+	case *ast.IndexExpression:
+		if target.Optional {
+			parser.addError(diagnostic.INVALID_ASSIGNMENT, target.Span(), fmt.Sprintf("Invalid assignment target: %s", target.String()))
+			return nil
+		}
+	default:
+		parser.addError(diagnostic.INVALID_ASSIGNMENT, target.Span(), fmt.Sprintf("Invalid assignment target: %s", target.String()))
+		return nil
+	}
+
+	parser.nextToken()
+	assignExpression.Value = parser.parseExpression(LOWEST)
+	if assignExpression.Value == nil {
+		return nil
+	}
+
+	return assignExpression
+}
+
+func (parser *Parser) parseCallExpression(function ast.Expression) ast.Expression {
+	callExpression := &ast.CallExpression{
+		Token:    parser.tok,
+		Function: function,
+	}
+
+	callExpression.Arguments = parser.parseExpressionList(token.RPAREN, parser.parseCallArgument)
+	if callExpression.Arguments == nil {
+		return nil
+	}
+
+	named := false
+	for _, argument := range callExpression.Arguments {
+		if _, ok := argument.(*ast.NamedArgument); ok {
+			named = true
+		} else if named {
+			parser.addError(diagnostic.INVALID_ARGUMENT, argument.Span(), fmt.Sprintf("Positional argument after named argument: %s", argument.String()))
+			return nil
+		}
+	}
+
+	return callExpression
+}
+
+func (parser *Parser) parseCallArgument() ast.Expression {
+	if parser.tok.Type != token.IDENTIFIER || parser.nextTok.Type != token.COLON {
+		return parser.parseExpression(LOWEST)
+	}
+
+	namedArgument := &ast.NamedArgument{
+		Token: parser.tok,
+		Name: &ast.Identifier{
+			Token: parser.tok,
+			Value: parser.tok.Literal,
+		},
+	}
+
+	parser.nextToken()
+	parser.nextToken()
+
+	namedArgument.Value = parser.parseExpression(LOWEST)
+	if namedArgument.Value == nil {
+		return nil
+	}
+
+	return namedArgument
+}
+
+func (parser *Parser) parseBoolean() ast.Expression {
+	return &ast.Boolean{
+		Token: parser.tok,
+		Value: parser.tok.Type == token.TRUE,
+	}
+}
+
+func (parser *Parser) parseGroupedExpression() ast.Expression {
+	if parser.isArrowFunction() {
+		tok := parser.tok
+
+		parameters := parser.parseFunctionParameters()
+		if parameters == nil {
+			return nil
+		}
+
+		return parser.parseArrowFunction(tok, parameters)
+	}
+
+	parser.nextToken()
+
+	arrowDisabled := parser.arrowDisabled
+	parser.arrowDisabled = false
+	expression := parser.parseExpression(LOWEST)
+	parser.arrowDisabled = arrowDisabled
+	if expression == nil {
+		return nil
+	}
+
+	if !parser.expectNextTokenType(token.RPAREN) {
+		return nil
+	}
+
+	return expression
+}
+
+func (parser *Parser) parseIfExpression() ast.Expression {
+	ifExpression := &ast.IfExpression{
+		Token: parser.tok,
+	}
+
+	if !parser.expectNextTokenType(token.LPAREN) {
+		return nil
+	}
+
+	parser.nextToken()
+
+	ifExpression.Condition = parser.parseExpression(LOWEST)
+	if ifExpression.Condition == nil {
+		return nil
+	}
+
+	if !parser.expectNextTokenType(token.RPAREN) {
+		return nil
+	}
+
+	if !parser.expectNextTokenType(token.LBRACE) {
+		return nil
+	}
+
+	ifExpression.Consequence = parser.parseBlockStatement()
+	if ifExpression.Consequence == nil {
+		return nil
+	}
+
+	if parser.nextTok.Type == token.ELSE {
+		parser.nextToken()
+
+		if parser.nextTok.Type == token.IF {
+			parser.nextToken()
+			tok := parser.tok
+
+			alternative := parser.parseIfExpression()
+			if alternative == nil {
+				return nil
+			}
+
+			ifExpression.Alternative = &ast.BlockStatement{
+				Token: tok,
+				Statements: []ast.Statement{
+					&ast.ExpressionStatement{
+						Token: tok,
+						Value: alternative,
+					},
+				},
+			}
+			return ifExpression
+		}
+
+		if !parser.expectNextTokenType(token.LBRACE) {
+			return nil
+		}
+		ifExpression.Alternative = parser.parseBlockStatement()
+		if ifExpression.Alternative == nil {
+			return nil
+		}
+	}
+
+	return ifExpression
+}
+
+func (parser *Parser) parseMatchExpression() ast.Expression {
+	matchExpression := &ast.MatchExpression{
+		Token: parser.tok,
+		Arms:  []*ast.MatchArm{},
+	}
+
+	if !parser.expectNextTokenType(token.LPAREN) {
+		return nil
 	}
 
-	prec := parser.getPrecedence()
 	parser.nextToken()
-	infixExpression.Right = parser.parseExpression(prec)
 
-	return infixExpression
-}
+	matchExpression.Subject = parser.parseExpression(LOWEST)
+	if matchExpression.Subject == nil {
+		return nil
+	}
 
-func (parser *Parser) parseCallExpression(function ast.Expression) ast.Expression {
-	callExpression := &ast.CallExpression{
-		Token:    parser.tok,
-		Function: function,
+	if !parser.expectNextTokenType(token.RPAREN) {
+		return nil
 	}
 
-	callExpression.Arguments = parser.parseExpressionList(token.RPAREN)
-	if callExpression.Arguments == nil {
+	if !parser.expectNextTokenType(token.LBRACE) {
 		return nil
 	}
 
-	return callExpression
-}
+	for parser.nextTok.Type != token.RBRACE {
+		parser.nextToken()
 
-func (parser *Parser) parseBoolean() ast.Expression {
-	return &ast.Boolean{
-		Token: parser.tok,
-		Value: parser.tok.Type == token.TRUE,
-	}
-}
+		arm := parser.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		matchExpression.Arms = append(matchExpression.Arms, arm)
 
-func (parser *Parser) parseGroupedExpression() ast.Expression {
-	parser.nextToken()
+		if parser.nextTok.Type == token.COMMA {
+			parser.nextToken()
+			continue
+		}
 
-	expression := parser.parseExpression(LOWEST)
+		if _, ok := arm.Body.(*ast.BlockStatement); !ok && parser.nextTok.Type != token.RBRACE {
+			parser.addInvalidNextTokenTypeError(parser.nextTok, token.COMMA)
+			return nil
+		}
+	}
 
-	if !parser.expectNextTokenType(token.RPAREN) {
+	if !parser.expectNextTokenType(token.RBRACE) {
 		return nil
 	}
 
-	return expression
+	return matchExpression
 }
 
-func (parser *Parser) parseIfExpression() ast.Expression {
-	ifExpression := &ast.IfExpression{
-		Token: parser.tok,
+func (parser *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{}
+
+	arrowDisabled := parser.arrowDisabled
+	parser.arrowDisabled = true
+	arm.Pattern = parser.parseExpression(LOWEST)
+	parser.arrowDisabled = arrowDisabled
+	if arm.Pattern == nil {
+		return nil
 	}
 
-	if !parser.expectNextTokenType(token.LPAREN) {
+	if !parser.validatePattern(arm.Pattern) {
 		return nil
 	}
 
-	parser.nextToken()
+	if parser.nextTok.Type == token.IF {
+		parser.nextToken()
+		parser.nextToken()
 
-	ifExpression.Condition = parser.parseExpression(LOWEST)
+		parser.arrowDisabled = true
+		arm.Guard = parser.parseExpression(LOWEST)
+		parser.arrowDisabled = arrowDisabled
+		if arm.Guard == nil {
+			return nil
+		}
+	}
 
-	if !parser.expectNextTokenType(token.RPAREN) {
+	if !parser.expectNextTokenType(token.ARROW) {
 		return nil
 	}
 
-	if !parser.expectNextTokenType(token.LBRACE) {
+	if parser.nextTok.Type == token.LBRACE {
+		parser.nextToken()
+
+		body := parser.parseBlockStatement()
+		if body == nil {
+			return nil
+		}
+		arm.Body = body
+
+		return arm
+	}
+
+	parser.nextToken()
+	body := &ast.ExpressionStatement{
+		Token: parser.tok,
+	}
+
+	body.Value = parser.parseExpression(LOWEST)
+	if body.Value == nil {
 		return nil
 	}
+	arm.Body = body
 
-	ifExpression.Consequence = parser.parseBlockStatement()
+	return arm
+}
 
-	if parser.nextTok.Type == token.ELSE {
-		parser.nextToken()
-		if !parser.expectNextTokenType(token.LBRACE) {
-			return nil
+func (parser *Parser) validatePattern(pattern ast.Expression) bool {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier, *ast.IntegerLiteral, *ast.FloatLiteral, *ast.StringLiteral, *ast.Boolean:
+		return true
+	case *ast.PrefixExpression:
+		switch pattern.Right.(type) {
+		case *ast.IntegerLiteral, *ast.FloatLiteral:
+			if pattern.Operator == "-" {
+				return true
+			}
 		}
-		ifExpression.Alternative = parser.parseBlockStatement()
+	case *ast.ArrayLiteral:
+		for i, element := range pattern.Value {
+			if rest, ok := element.(*ast.SpreadExpression); ok {
+				if _, ok := rest.Value.(*ast.Identifier); !ok || i != len(pattern.Value)-1 {
+					parser.addError(diagnostic.INVALID_PATTERN, rest.Span(), fmt.Sprintf("Invalid rest pattern: %s", rest.String()))
+					return false
+				}
+				continue
+			}
+
+			if !parser.validatePattern(element) {
+				return false
+			}
+		}
+		return true
+	case *ast.HashLiteral:
+		for key, value := range pattern.Value {
+			switch key.(type) {
+			case *ast.IntegerLiteral, *ast.StringLiteral, *ast.Boolean:
+			default:
+				parser.addError(diagnostic.INVALID_PATTERN, key.Span(), fmt.Sprintf("Invalid pattern key: %s", key.String()))
+				return false
+			}
+
+			if !parser.validatePattern(value) {
+				return false
+			}
+		}
+		return true
 	}
 
-	return ifExpression
+	parser.addError(diagnostic.INVALID_PATTERN, pattern.Span(), fmt.Sprintf("Invalid pattern: %s", pattern.String()))
+	return false
 }
 
 func (parser *Parser) parseBlockStatement() *ast.BlockStatement {
@@ -377,13 +1279,21 @@ func (parser *Parser) parseBlockStatement() *ast.BlockStatement {
 	parser.nextToken()
 
 	for parser.tok.Type != token.RBRACE && parser.tok.Type != token.EOF {
+		braceDepth := parser.statementBraceDepth()
 		statement := parser.parseStatement()
 		if statement != nil {
 			blockStatement.Statements = append(blockStatement.Statements, statement)
+		} else if parser.synchronize(braceDepth) {
+			break
 		}
 		parser.nextToken()
 	}
 
+	if parser.tok.Type != token.RBRACE {
+		parser.addInvalidNextTokenTypeError(parser.tok, token.RBRACE)
+		return nil
+	}
+
 	return blockStatement
 }
 
@@ -392,6 +1302,15 @@ func (parser *Parser) parseFunctionLiteral() ast.Expression {
 		Token: parser.tok,
 	}
 
+	if parser.nextTok.Type == token.IDENTIFIER {
+		parser.nextToken()
+
+		functionLiteral.Name = &ast.Identifier{
+			Token: parser.tok,
+			Value: parser.tok.Literal,
+		}
+	}
+
 	if !parser.expectNextTokenType(token.LPAREN) {
 		return nil
 	}
@@ -405,43 +1324,231 @@ func (parser *Parser) parseFunctionLiteral() ast.Expression {
 		return nil
 	}
 
+	loopDepth := parser.loopDepth
+	parser.loopDepth = 0
 	functionLiteral.Body = parser.parseBlockStatement()
+	parser.loopDepth = loopDepth
+	if functionLiteral.Body == nil {
+		return nil
+	}
+	ast.MarkTailCalls(functionLiteral.Body)
+
+	return functionLiteral
+}
+
+func (parser *Parser) isArrowFunction() bool {
+	depth := 1
+	tok := parser.nextTok
+	for i := 1; ; i++ {
+		switch tok.Type {
+		case token.LPAREN, token.LBRACKET, token.LBRACE:
+			depth += 1
+		case token.RPAREN, token.RBRACKET, token.RBRACE:
+			depth -= 1
+			if depth == 0 {
+				return tok.Type == token.RPAREN && parser.lex.Peek(i)[i-1].Type == token.ARROW
+			}
+		case token.EOF:
+			return false
+		}
+
+		tok = parser.lex.Peek(i)[i-1]
+	}
+}
+
+func (parser *Parser) parseArrowFunction(tok token.Token, parameters []ast.Expression) ast.Expression {
+	functionLiteral := &ast.FunctionLiteral{
+		Token:      tok,
+		Parameters: parameters,
+	}
+
+	if !parser.expectNextTokenType(token.ARROW) {
+		return nil
+	}
+
+	loopDepth := parser.loopDepth
+	parser.loopDepth = 0
+	if parser.nextTok.Type == token.LBRACE {
+		parser.nextToken()
+		functionLiteral.Body = parser.parseBlockStatement()
+	} else {
+		parser.nextToken()
+		functionLiteral.Body = parser.parseArrowBody()
+	}
+	parser.loopDepth = loopDepth
+	if functionLiteral.Body == nil {
+		return nil
+	}
+	ast.MarkTailCalls(functionLiteral.Body)
 
 	return functionLiteral
 }
 
-func (parser *Parser) parseFunctionParameters() []*ast.Identifier {
-	identifiers := []*ast.Identifier{}
+func (parser *Parser) parseArrowBody() *ast.BlockStatement {
+	tok := parser.tok
+
+	value := parser.parseExpression(LOWEST)
+	if value == nil {
+		return nil
+	}
+
+	return &ast.BlockStatement{
+		Token: tok,
+		Statements: []ast.Statement{
+			&ast.ExpressionStatement{
+				Token: tok,
+				Value: value,
+			},
+		},
+	}
+}
+
+func (parser *Parser) parseConditionalExpression(condition ast.Expression) ast.Expression {
+	conditionalExpression := &ast.ConditionalExpression{
+		Token:     parser.tok,
+		Condition: condition,
+	}
+
 	parser.nextToken()
 
-	if parser.tok.Type == token.RPAREN {
-		return identifiers
+	conditionalExpression.Consequence = parser.parseExpression(LOWEST)
+	if conditionalExpression.Consequence == nil {
+		return nil
+	}
+
+	if !parser.expectNextTokenType(token.COLON) {
+		return nil
 	}
 
-	identifier := &ast.Identifier{
+	parser.nextToken()
+
+	conditionalExpression.Alternative = parser.parseExpression(ASSIGNMENT)
+	if conditionalExpression.Alternative == nil {
+		return nil
+	}
+
+	return conditionalExpression
+}
+
+func (parser *Parser) parsePipelineExpression(left ast.Expression) ast.Expression {
+	pipelineExpression := &ast.PipelineExpression{
 		Token: parser.tok,
-		Value: parser.tok.Literal,
+		Left:  left,
+	}
+
+	parser.nextToken()
+
+	pipelineExpression.Right = parser.parseExpression(PIPELINE)
+	if pipelineExpression.Right == nil {
+		return nil
+	}
+
+	return pipelineExpression
+}
+
+func (parser *Parser) parseFunctionParameters() []ast.Expression {
+	parameters := []ast.Expression{}
+
+	if parser.nextTok.Type == token.RPAREN {
+		parser.nextToken()
+		return parameters
+	}
+
+	for {
+		if parameter := parser.parseFunctionParameter(); parameter != nil {
+			parameters = append(parameters, parameter)
+		} else {
+			if !parser.synchronizeList(token.RPAREN) {
+				return nil
+			}
+			if parser.tok.Type == token.COMMA {
+				continue
+			}
+			if parser.tok.Type == token.RPAREN {
+				return parameters
+			}
+		}
+
+		if parser.nextTok.Type != token.COMMA {
+			break
+		}
+		parser.nextToken()
+	}
+
+	if !parser.expectNextTokenType(token.RPAREN) {
+		return nil
+	}
+
+	for i, parameter := range parameters {
+		if _, ok := parameter.(*ast.SpreadExpression); ok && i != len(parameters)-1 {
+			parser.addError(diagnostic.INVALID_PARAMETER, parameter.Span(), fmt.Sprintf("Rest parameter must be last: %s", parameter.String()))
+			return nil
+		}
 	}
-	identifiers = append(identifiers, identifier)
 
-	for parser.nextTok.Type == token.COMMA {
+	return parameters
+}
+
+func (parser *Parser) parseFunctionParameter() ast.Expression {
+	var parameter ast.Expression
+
+	switch parser.nextTok.Type {
+	case token.ELLIPSIS:
 		parser.nextToken()
+
+		rest := parser.parseSpreadExpression()
+		if rest == nil {
+			return nil
+		}
+
+		if _, ok := rest.(*ast.SpreadExpression).Value.(*ast.Identifier); !ok {
+			parser.addError(diagnostic.INVALID_PARAMETER, rest.Span(), fmt.Sprintf("Invalid rest parameter: %s", rest.String()))
+			return nil
+		}
+		return rest
+	case token.LBRACKET, token.LBRACE:
 		parser.nextToken()
-		identifier := &ast.Identifier{
+
+		parameter = parser.parseExpression(ASSIGNMENT)
+		if parameter == nil || !parser.validatePattern(parameter) {
+			return nil
+		}
+	default:
+		if !parser.expectNextTokenType(token.IDENTIFIER) {
+			parser.nextToken()
+			return nil
+		}
+
+		parameter = &ast.Identifier{
 			Token: parser.tok,
 			Value: parser.tok.Literal,
 		}
-		identifiers = append(identifiers, identifier)
 	}
 
-	if !parser.expectNextTokenType(token.RPAREN) {
+	if parser.nextTok.Type != token.ASSIGN {
+		return parameter
+	}
+
+	parser.nextToken()
+	defaultParameter := &ast.DefaultParameter{
+		Token: parser.tok,
+		Name:  parameter,
+	}
+
+	parser.nextToken()
+	defaultParameter.Value = parser.parseExpression(LOWEST)
+	if defaultParameter.Value == nil {
 		return nil
 	}
 
-	return identifiers
+	return defaultParameter
+}
+
+func (parser *Parser) parseListElement() ast.Expression {
+	return parser.parseExpression(LOWEST)
 }
 
-func (parser *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
+func (parser *Parser) parseExpressionList(end token.TokenType, parseElement prefixParser) []ast.Expression {
 	expressions := []ast.Expression{}
 
 	if parser.nextTok.Type == end {
@@ -449,13 +1556,33 @@ func (parser *Parser) parseExpressionList(end token.TokenType) []ast.Expression
 		return expressions
 	}
 
-	parser.nextToken()
-	expressions = append(expressions, parser.parseExpression(LOWEST))
+	arrowDisabled := parser.arrowDisabled
+	parser.arrowDisabled = false
+	defer func() {
+		parser.arrowDisabled = arrowDisabled
+	}()
 
-	for parser.nextTok.Type == token.COMMA {
+	for {
 		parser.nextToken()
+		expression := parseElement()
+		if expression != nil {
+			expressions = append(expressions, expression)
+		} else {
+			if !parser.synchronizeList(end) {
+				return nil
+			}
+			if parser.tok.Type == token.COMMA {
+				continue
+			}
+			if parser.tok.Type == end {
+				return expressions
+			}
+		}
+
+		if parser.nextTok.Type != token.COMMA {
+			break
+		}
 		parser.nextToken()
-		expressions = append(expressions, parser.parseExpression(LOWEST))
 	}
 
 	if !parser.expectNextTokenType(end) {
@@ -472,12 +1599,37 @@ func (parser *Parser) parseStringLiteral() ast.Expression {
 	}
 }
 
+func (parser *Parser) parseInterpolatedString() ast.Expression {
+	interpolatedString := &ast.InterpolatedString{
+		Token: parser.tok,
+		Parts: []ast.Expression{parser.parseStringLiteral()},
+	}
+
+	for parser.tok.Type != token.STRING_TAIL {
+		parser.nextToken()
+		expression := parser.parseExpression(LOWEST)
+		if expression == nil {
+			return nil
+		}
+		interpolatedString.Parts = append(interpolatedString.Parts, expression)
+
+		if parser.nextTok.Type == token.STRING_MIDDLE {
+			parser.nextToken()
+		} else if !parser.expectNextTokenType(token.STRING_TAIL) {
+			return nil
+		}
+		interpolatedString.Parts = append(interpolatedString.Parts, parser.parseStringLiteral())
+	}
+
+	return interpolatedString
+}
+
 func (parser *Parser) parseArrayLiteral() ast.Expression {
 	arrayLiteral := &ast.ArrayLiteral{
 		Token: parser.tok,
 	}
 
-	arrayLiteral.Value = parser.parseExpressionList(token.RBRACKET)
+	arrayLiteral.Value = parser.parseExpressionList(token.RBRACKET, parser.parseListElement)
 	if arrayLiteral.Value == nil {
 		return nil
 	}
@@ -493,19 +1645,31 @@ func (parser *Parser) parseHashLiteral() ast.Expression {
 
 	for parser.nextTok.Type != token.RBRACE {
 		parser.nextToken()
-		key := parser.parseExpression(LOWEST)
-
-		if !parser.expectNextTokenType(token.COLON) {
-			return nil
+		key, value := parser.parseHashPair()
+		if key != nil && value != nil {
+			hashLiteral.Value[key] = value
+		} else {
+			if !parser.synchronizeList(token.RBRACE) {
+				return nil
+			}
+			if parser.tok.Type == token.RBRACE {
+				return hashLiteral
+			}
+			if parser.tok.Type == token.COMMA {
+				continue
+			}
 		}
 
-		parser.nextToken()
-		value := parser.parseExpression(LOWEST)
-
-		hashLiteral.Value[key] = value
-
-		if parser.nextTok.Type != token.RBRACE && !parser.expectNextTokenType(token.COMMA) {
-			return nil
+		switch parser.nextTok.Type {
+		case token.RBRACE:
+		case token.COMMA:
+			parser.nextToken()
+		default:
+			parser.addInvalidNextTokenTypeError(parser.nextTok, token.COMMA)
+			if parser.nextTok.Type == token.SEMICOLON || parser.nextTok.Type == token.EOF {
+				return nil
+			}
+			parser.panicking = false
 		}
 	}
 
@@ -516,15 +1680,43 @@ func (parser *Parser) parseHashLiteral() ast.Expression {
 	return hashLiteral
 }
 
+func (parser *Parser) parseHashPair() (ast.Expression, ast.Expression) {
+	key := parser.parseExpression(LOWEST)
+	if key == nil {
+		return nil, nil
+	}
+
+	if !parser.expectNextTokenType(token.COLON) {
+		return nil, nil
+	}
+
+	parser.nextToken()
+	value := parser.parseExpression(LOWEST)
+
+	return key, value
+}
+
 func (parser *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	indexExpression := &ast.IndexExpression{
-		Token: parser.tok,
-		Left:  left,
+		Token:    parser.tok,
+		Left:     left,
+		Optional: parser.tok.Type == token.OPTIONAL_INDEX,
+	}
+
+	if parser.nextTok.Type == token.COLON {
+		return parser.parseSliceExpression(indexExpression.Token, left, nil)
 	}
 
 	parser.nextToken()
 
 	indexExpression.Index = parser.parseExpression(LOWEST)
+	if indexExpression.Index == nil {
+		return nil
+	}
+
+	if parser.nextTok.Type == token.COLON {
+		return parser.parseSliceExpression(indexExpression.Token, left, indexExpression.Index)
+	}
 
 	if !parser.expectNextTokenType(token.RBRACKET) {
 		return nil
@@ -533,6 +1725,32 @@ func (parser *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	return indexExpression
 }
 
+func (parser *Parser) parseSliceExpression(tok token.Token, left ast.Expression, start ast.Expression) ast.Expression {
+	sliceExpression := &ast.SliceExpression{
+		Token:    tok,
+		Left:     left,
+		Start:    start,
+		Optional: tok.Type == token.OPTIONAL_INDEX,
+	}
+
+	parser.nextToken()
+
+	if parser.nextTok.Type != token.RBRACKET {
+		parser.nextToken()
+
+		sliceExpression.End = parser.parseExpression(LOWEST)
+		if sliceExpression.End == nil {
+			return nil
+		}
+	}
+
+	if !parser.expectNextTokenType(token.RBRACKET) {
+		return nil
+	}
+
+	return sliceExpression
+}
+
 func (parser *Parser) parseMacroLiteral() ast.Expression {
 	macroLiteral := &ast.MacroLiteral{
 		Token: parser.tok,
@@ -542,13 +1760,29 @@ func (parser *Parser) parseMacroLiteral() ast.Expression {
 		return nil
 	}
 
-	macroLiteral.Parameters = parser.parseFunctionParameters()
+	parameters := parser.parseFunctionParameters()
+	if parameters == nil {
+		return nil
+	}
+
+	macroLiteral.Parameters = make([]*ast.Identifier, 0, len(parameters))
+	for _, parameter := range parameters {
+		identifier, ok := parameter.(*ast.Identifier)
+		if !ok {
+			parser.addError(diagnostic.INVALID_PATTERN, parameter.Span(), fmt.Sprintf("Invalid macro parameter: %s", parameter.String()))
+			return nil
+		}
+		macroLiteral.Parameters = append(macroLiteral.Parameters, identifier)
+	}
 
 	if !parser.expectNextTokenType(token.LBRACE) {
 		return nil
 	}
 
 	macroLiteral.Body = parser.parseBlockStatement()
+	if macroLiteral.Body == nil {
+		return nil
+	}
 
 	return macroLiteral
 }
@@ -3,8 +3,10 @@ package parser
 import (
 	"fmt"
 	"leonardjouve/ast"
+	"leonardjouve/diagnostic"
 	"leonardjouve/lexer"
 	"leonardjouve/token"
+	"reflect"
 	"testing"
 )
 
@@ -49,6 +51,106 @@ func TestLetStatements(t *testing.T) {
 	}
 }
 
+func TestConstStatements(t *testing.T) {
+	type ConstStatementTest struct {
+		input              string
+		expectedIdentifier string
+		expectedValue      interface{}
+	}
+	tests := []ConstStatementTest{
+		{
+			input:              "const x = 5;",
+			expectedIdentifier: "x",
+			expectedValue:      5,
+		},
+		{
+			input:              "const LIMIT = true",
+			expectedIdentifier: "LIMIT",
+			expectedValue:      true,
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		constStatement, ok := program.Statements[0].(*ast.ConstStatement)
+		if !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ConstStatement", program.Statements[0])
+		}
+
+		if constStatement.TokenLiteral() != "const" {
+			t.Fatalf("[Test] Invalid token literal: received %s, expected const", constStatement.TokenLiteral())
+		}
+
+		testIdentifier(t, constStatement.Name, token.TokenLiteral(test.expectedIdentifier))
+		testLiteralExpression(t, constStatement.Value, test.expectedValue)
+
+		if expectedString := "const " + test.expectedIdentifier + " = " + constStatement.Value.String() + ";"; program.String() != expectedString {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), expectedString)
+		}
+	}
+}
+
+func TestDestructuringLetStatements(t *testing.T) {
+	type DestructuringLetStatementTest struct {
+		input    string
+		expected string
+	}
+	tests := []DestructuringLetStatementTest{
+		{
+			input:    "let [a, b] = arr;",
+			expected: "let [a, b] = arr;",
+		},
+		{
+			input:    "let [first, ...rest] = arr;",
+			expected: "let [first, ...rest] = arr;",
+		},
+		{
+			input:    "let {\"name\": n} = person;",
+			expected: "let {\"name\": n} = person;",
+		},
+		{
+			input:    "let [{\"x\": x}, [y, _]] = points;",
+			expected: "let [{\"x\": x}, [y, _]] = points;",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		letStatement, ok := program.Statements[0].(*ast.LetStatement)
+		if !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.LetStatement", program.Statements[0])
+		}
+
+		switch letStatement.Name.(type) {
+		case *ast.ArrayLiteral, *ast.HashLiteral:
+		default:
+			t.Errorf("[Test] Invalid let statement name type: received %T, expected pattern", letStatement.Name)
+		}
+
+		if program.String() != test.expected {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test.expected)
+		}
+	}
+}
+
 func TestReturnStatement(t *testing.T) {
 	type ReturnStatementTest struct {
 		input    string
@@ -164,6 +266,53 @@ func TestIntegerExpressions(t *testing.T) {
 	}
 }
 
+func TestFloatExpressions(t *testing.T) {
+	type FloatExpressionTest struct {
+		input    string
+		expected float64
+	}
+	tests := []FloatExpressionTest{
+		{
+			input:    "1.5;",
+			expected: 1.5,
+		},
+		{
+			input:    ".25;",
+			expected: 0.25,
+		},
+		{
+			input:    "1e-3;",
+			expected: 0.001,
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		expressionStatement, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.EpressionStatement", program.Statements[0])
+		}
+
+		float, ok := expressionStatement.Value.(*ast.FloatLiteral)
+		if !ok {
+			t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.FloatLiteral", expressionStatement.Value)
+		}
+
+		if float.Value != test.expected {
+			t.Errorf("[Test] Invalid float value: received %g, expected %g", float.Value, test.expected)
+		}
+	}
+}
+
 func TestPrefixExpressions(t *testing.T) {
 	type PrefixTest struct {
 		input    string
@@ -280,6 +429,66 @@ func TestInfixExpressions(t *testing.T) {
 			left:     5,
 			right:    15,
 		},
+		{
+			input:    "5 % 15;",
+			operator: "%",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 <= 15;",
+			operator: "<=",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 >= 15;",
+			operator: ">=",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 && 15;",
+			operator: "&&",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 || 15;",
+			operator: "||",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 & 15;",
+			operator: "&",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 | 15;",
+			operator: "|",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 ^ 15;",
+			operator: "^",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 << 15;",
+			operator: "<<",
+			left:     5,
+			right:    15,
+		},
+		{
+			input:    "5 >> 15;",
+			operator: ">>",
+			left:     5,
+			right:    15,
+		},
 		{
 			input:    "true == true",
 			operator: "==",
@@ -338,6 +547,78 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			input:    "a + b - c",
 			expected: "((a + b) - c)",
 		},
+		{
+			input:    "x = y = 5",
+			expected: "(x = (y = 5))",
+		},
+		{
+			input:    "x += a * b",
+			expected: "(x += (a * b))",
+		},
+		{
+			input:    "a[i] = b || c",
+			expected: "((a[i]) = (b || c))",
+		},
+		{
+			input:    "x -= 1 == 2",
+			expected: "(x -= (1 == 2))",
+		},
+		{
+			input:    "a[1:2]",
+			expected: "(a[1:2])",
+		},
+		{
+			input:    "a[:b + 1]",
+			expected: "(a[:(b + 1)])",
+		},
+		{
+			input:    "a[1:]",
+			expected: "(a[1:])",
+		},
+		{
+			input:    "a[:]",
+			expected: "(a[:])",
+		},
+		{
+			input:    "a[1:2][0]",
+			expected: "((a[1:2])[0])",
+		},
+		{
+			input:    "a % b * c",
+			expected: "((a % b) * c)",
+		},
+		{
+			input:    "a + b % c",
+			expected: "(a + (b % c))",
+		},
+		{
+			input:    "a <= b == b >= a",
+			expected: "((a <= b) == (b >= a))",
+		},
+		{
+			input:    "a < b && b < c || d",
+			expected: "(((a < b) && (b < c)) || d)",
+		},
+		{
+			input:    "a || b && c",
+			expected: "(a || (b && c))",
+		},
+		{
+			input:    "a | b ^ c & d",
+			expected: "(a | (b ^ (c & d)))",
+		},
+		{
+			input:    "a & b == c",
+			expected: "(a & (b == c))",
+		},
+		{
+			input:    "a << 1 + 2 < b >> 3",
+			expected: "((a << (1 + 2)) < (b >> 3))",
+		},
+		{
+			input:    "x >= 0 && x < n",
+			expected: "((x >= 0) && (x < n))",
+		},
 		{
 			input:    "a * b * c",
 			expected: "((a * b) * c)",
@@ -426,6 +707,78 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			input:    "add(a * b[2], b[1], 2 * [1, 2][1])",
 			expected: "add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))",
 		},
+		{
+			input:    "a |> f(b) |> g",
+			expected: "((a |> f(b)) |> g)",
+		},
+		{
+			input:    "a + 1 |> f",
+			expected: "((a + 1) |> f)",
+		},
+		{
+			input:    "a || b |> f",
+			expected: "((a || b) |> f)",
+		},
+		{
+			input:    "x = a |> f",
+			expected: "(x = (a |> f))",
+		},
+		{
+			input:    "(a + b) * c",
+			expected: "((a + b) * c)",
+		},
+		{
+			input:    "xs |> map(x => x * 2)",
+			expected: "(xs |> map(fn (x) (x * 2)))",
+		},
+		{
+			input:    "a ? b : c",
+			expected: "(a ? b : c)",
+		},
+		{
+			input:    "a ? b : c ? d : e",
+			expected: "(a ? b : (c ? d : e))",
+		},
+		{
+			input:    "a ? b ? c : d : e",
+			expected: "(a ? (b ? c : d) : e)",
+		},
+		{
+			input:    "x = a > 1 ? b + 1 : c",
+			expected: "(x = ((a > 1) ? (b + 1) : c))",
+		},
+		{
+			input:    "a ?? b ?? c",
+			expected: "((a ?? b) ?? c)",
+		},
+		{
+			input:    "a || b ?? c",
+			expected: "((a || b) ?? c)",
+		},
+		{
+			input:    "a ?? b ? c : d",
+			expected: "((a ?? b) ? c : d)",
+		},
+		{
+			input:    "a?[b]?[c] ?? d",
+			expected: "(((a?[b])?[c]) ?? d)",
+		},
+		{
+			input:    "a?[1:2]",
+			expected: "(a?[1:2])",
+		},
+		{
+			input:    "c ?[1] : [2]",
+			expected: "(c ? [1] : [2])",
+		},
+		{
+			input:    "f(x)?[0] ?? (c ?[x]: [])",
+			expected: "((f(x)?[0]) ?? (c ? [x] : []))",
+		},
+		{
+			input:    "f(a ? b : c, d)",
+			expected: "f((a ? b : c), d)",
+		},
 	}
 
 	for _, test := range tests {
@@ -503,8 +856,8 @@ func TestIfExpressions(t *testing.T) {
 	}
 }
 
-func TestFunctionLiteralParsing(t *testing.T) {
-	input := "fn (x, y) { x + y; }"
+func TestElseIfExpressions(t *testing.T) {
+	input := "if (x < 0) { a } else if (x > 0) { b } else { c }"
 
 	lex := lexer.New(input)
 	parser := New(lex)
@@ -521,54 +874,71 @@ func TestFunctionLiteralParsing(t *testing.T) {
 		t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
 	}
 
-	functionLiteral, ok := expressionStatement.Value.(*ast.FunctionLiteral)
+	ifExpression, ok := expressionStatement.Value.(*ast.IfExpression)
 	if !ok {
-		t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.FunctionLiteral", expressionStatement.Value)
+		t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.IfExpression", expressionStatement.Value)
 	}
 
-	expectedParamsAmount := 2
-	if paramsAmount := len(functionLiteral.Parameters); paramsAmount != expectedParamsAmount {
-		t.Fatalf("[Test] Invalid function params amount: received %d, expected %d", paramsAmount, expectedParamsAmount)
+	expectedAlternativeAmount := 1
+	if alternativeAmount := len(ifExpression.Alternative.Statements); alternativeAmount != expectedAlternativeAmount {
+		t.Fatalf("[Test] Invalid alternative statement amount: received %d, expected %d", alternativeAmount, expectedAlternativeAmount)
 	}
 
-	testLiteralExpression(t, functionLiteral.Parameters[0], token.TokenLiteral("x"))
-	testLiteralExpression(t, functionLiteral.Parameters[1], token.TokenLiteral("y"))
-
-	expectedBodyStatementAmount := 1
-	if bodyStatementAmount := len(functionLiteral.Body.Statements); bodyStatementAmount != expectedBodyStatementAmount {
-		t.Fatalf("[Test] Invalid body statement amount: received %d, expected %d", bodyStatementAmount, expectedBodyStatementAmount)
+	alternative, ok := ifExpression.Alternative.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("[Test] Invalid alternative type: received %T, expected *ast.ExpressionStatement", ifExpression.Alternative.Statements[0])
 	}
 
-	bodyStatement, ok := functionLiteral.Body.Statements[0].(*ast.ExpressionStatement)
+	elseIf, ok := alternative.Value.(*ast.IfExpression)
 	if !ok {
-		t.Fatalf("[Test] Invalid body statement type: received %T, expected *ast.ExpressionStatement", functionLiteral.Body.Statements[0])
+		t.Fatalf("[Test] Invalid alternative expression type: received %T, expected *ast.IfExpression", alternative.Value)
 	}
 
-	testInfixExpression(t, bodyStatement.Value, "+", token.TokenLiteral("x"), token.TokenLiteral("y"))
+	if !testInfixExpression(t, elseIf.Condition, ">", token.TokenLiteral("x"), 0) {
+		return
+	}
+
+	if elseIf.Alternative == nil {
+		t.Fatalf("[Test] Invalid else if alternative: received nil, expected *ast.BlockStatement")
+	}
+
+	expected := "if (x < 0) a else if (x > 0) b else c"
+	if program.String() != expected {
+		t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), expected)
+	}
 }
 
-func TestFunctionParametersParsing(t *testing.T) {
-	type FunctionParameterParsingTest struct {
-		input    string
-		expected []token.TokenLiteral
+func TestMatchExpressions(t *testing.T) {
+	type MatchExpressionTest struct {
+		input             string
+		expectedArmAmount int
+		expected          string
 	}
-	tests := []FunctionParameterParsingTest{
+	tests := []MatchExpressionTest{
 		{
-			input:    "fn () {}",
-			expected: []token.TokenLiteral{},
+			input:             "match (x) { 1 => \"one\", -2.5 => \"neg\", _ => \"other\" }",
+			expectedArmAmount: 3,
+			expected:          "match (x) { 1 => \"one\", (-2.5) => \"neg\", _ => \"other\" }",
 		},
 		{
-			input: "fn (x) {}",
-			expected: []token.TokenLiteral{
-				"x",
-			},
+			input:             "match (pair) { [a, b] if a > b => a, [a, _] => a }",
+			expectedArmAmount: 2,
+			expected:          "match (pair) { [a, b] if (a > b) => a, [a, _] => a }",
 		},
 		{
-			input: "fn (x, y) {}",
-			expected: []token.TokenLiteral{
-				"x",
-				"y",
-			},
+			input:             "match (point) { {\"x\": x} => { let y = x * 2; y } n => n }",
+			expectedArmAmount: 2,
+			expected:          "match (point) { {\"x\": x} => let y = (x * 2);y, n => n }",
+		},
+		{
+			input:             "match (x) { n if any(xs, y => y > n) => n }",
+			expectedArmAmount: 1,
+			expected:          "match (x) { n if any(xs, fn (y) (y > n)) => n }",
+		},
+		{
+			input:             "match (x) {}",
+			expectedArmAmount: 0,
+			expected:          "match (x) {  }",
 		},
 	}
 
@@ -588,9 +958,548 @@ func TestFunctionParametersParsing(t *testing.T) {
 			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
 		}
 
-		functionLiteral, ok := expressionStatement.Value.(*ast.FunctionLiteral)
+		matchExpression, ok := expressionStatement.Value.(*ast.MatchExpression)
 		if !ok {
-			t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.FunctionLiteral", expressionStatement.Value)
+			t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.MatchExpression", expressionStatement.Value)
+		}
+
+		if armAmount := len(matchExpression.Arms); armAmount != test.expectedArmAmount {
+			t.Errorf("[Test] Invalid match arm amount: received %d, expected %d", armAmount, test.expectedArmAmount)
+		}
+
+		if program.String() != test.expected {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test.expected)
+		}
+	}
+}
+
+func TestWhileStatements(t *testing.T) {
+	input := "while (x < y) { x += 1; if (x == 5) { break; } continue; }"
+
+	lex := lexer.New(input)
+	parser := New(lex)
+	program := parser.ParseProgram()
+	testParserErrors(t, parser)
+
+	expectedStatementAmount := 1
+	if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+		t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+	}
+
+	whileStatement, ok := program.Statements[0].(*ast.WhileStatement)
+	if !ok {
+		t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.WhileStatement", program.Statements[0])
+	}
+
+	if !testInfixExpression(t, whileStatement.Condition, "<", token.TokenLiteral("x"), token.TokenLiteral("y")) {
+		return
+	}
+
+	expectedBodyStatementAmount := 3
+	if bodyStatementAmount := len(whileStatement.Body.Statements); bodyStatementAmount != expectedBodyStatementAmount {
+		t.Fatalf("[Test] Invalid body statement amount: received %d, expected %d", bodyStatementAmount, expectedBodyStatementAmount)
+	}
+
+	if _, ok := whileStatement.Body.Statements[2].(*ast.ContinueStatement); !ok {
+		t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ContinueStatement", whileStatement.Body.Statements[2])
+	}
+
+	if expectedString := "while (x < y) (x += 1)if (x == 5) break;continue;"; program.String() != expectedString {
+		t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), expectedString)
+	}
+}
+
+func TestForStatements(t *testing.T) {
+	type ForStatementTest struct {
+		input    string
+		expected string
+	}
+	tests := []ForStatementTest{
+		{
+			input:    "for (let i = 0; i < n; i += 1) { puts(i); }",
+			expected: "for (let i = 0; (i < n); (i += 1)) puts(i)",
+		},
+		{
+			input:    "for (i = 0; i < n;) { break; }",
+			expected: "for ((i = 0); (i < n); ) break;",
+		},
+		{
+			input:    "for (;;) { break; }",
+			expected: "for (; ; ) break;",
+		},
+		{
+			input:    "for (;;) { break; };",
+			expected: "for (; ; ) break;",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		if _, ok := program.Statements[0].(*ast.ForStatement); !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ForStatement", program.Statements[0])
+		}
+
+		if program.String() != test.expected {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test.expected)
+		}
+	}
+}
+
+func TestForInStatements(t *testing.T) {
+	type ForInStatementTest struct {
+		input         string
+		expectedKey   string
+		expectedValue string
+		expected      string
+	}
+	tests := []ForInStatementTest{
+		{
+			input:         "for (x in arr) { puts(x); }",
+			expectedKey:   "",
+			expectedValue: "x",
+			expected:      "for (x in arr) puts(x)",
+		},
+		{
+			input:         "for (k, v in {\"a\": 1}) { puts(k, v); }",
+			expectedKey:   "k",
+			expectedValue: "v",
+			expected:      "for (k, v in {\"a\": 1}) puts(k, v)",
+		},
+		{
+			input:         "for (i in range(0, 10, 2)) { if (i > 4) { break; } };",
+			expectedKey:   "",
+			expectedValue: "i",
+			expected:      "for (i in range(0, 10, 2)) if (i > 4) break;",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		forInStatement, ok := program.Statements[0].(*ast.ForInStatement)
+		if !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ForInStatement", program.Statements[0])
+		}
+
+		if test.expectedKey == "" {
+			if forInStatement.Key != nil {
+				t.Errorf("[Test] Invalid for in key: received %s, expected nil", forInStatement.Key)
+			}
+		} else {
+			testLiteralExpression(t, forInStatement.Key, token.TokenLiteral(test.expectedKey))
+		}
+		testLiteralExpression(t, forInStatement.Value, token.TokenLiteral(test.expectedValue))
+
+		if program.String() != test.expected {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test.expected)
+		}
+	}
+}
+
+func TestTryStatements(t *testing.T) {
+	type TryStatementTest struct {
+		input             string
+		expectedParameter string
+		expectedCatch     bool
+		expectedFinally   bool
+		expected          string
+	}
+	tests := []TryStatementTest{
+		{
+			input:             "try { f(); } catch (e) { puts(e); }",
+			expectedParameter: "e",
+			expectedCatch:     true,
+			expectedFinally:   false,
+			expected:          "try f() catch (e) puts(e)",
+		},
+		{
+			input:             "try { f(); } catch { g(); } finally { h(); }",
+			expectedParameter: "",
+			expectedCatch:     true,
+			expectedFinally:   true,
+			expected:          "try f() catch g() finally h()",
+		},
+		{
+			input:             "try { f(); } finally { h(); }",
+			expectedParameter: "",
+			expectedCatch:     false,
+			expectedFinally:   true,
+			expected:          "try f() finally h()",
+		},
+		{
+			input:             "try { f(); } catch (e) { g(e); };",
+			expectedParameter: "e",
+			expectedCatch:     true,
+			expectedFinally:   false,
+			expected:          "try f() catch (e) g(e)",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		tryStatement, ok := program.Statements[0].(*ast.TryStatement)
+		if !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.TryStatement", program.Statements[0])
+		}
+
+		if test.expectedParameter == "" {
+			if tryStatement.Parameter != nil {
+				t.Errorf("[Test] Invalid catch parameter: received %s, expected nil", tryStatement.Parameter)
+			}
+		} else {
+			testLiteralExpression(t, tryStatement.Parameter, token.TokenLiteral(test.expectedParameter))
+		}
+
+		if hasCatch := tryStatement.Catch != nil; hasCatch != test.expectedCatch {
+			t.Errorf("[Test] Invalid catch block presence: received %t, expected %t", hasCatch, test.expectedCatch)
+		}
+		if hasFinally := tryStatement.Finally != nil; hasFinally != test.expectedFinally {
+			t.Errorf("[Test] Invalid finally block presence: received %t, expected %t", hasFinally, test.expectedFinally)
+		}
+
+		if program.String() != test.expected {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test.expected)
+		}
+	}
+}
+
+func TestThrowStatements(t *testing.T) {
+	type ThrowStatementTest struct {
+		input    string
+		expected string
+	}
+	tests := []ThrowStatementTest{
+		{
+			input:    "throw \"boom\";",
+			expected: "throw \"boom\"",
+		},
+		{
+			input:    "throw error(\"boom\", e);",
+			expected: "throw error(\"boom\", e)",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		if _, ok := program.Statements[0].(*ast.ThrowStatement); !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ThrowStatement", program.Statements[0])
+		}
+
+		if program.String() != test.expected {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test.expected)
+		}
+	}
+}
+
+func TestArrowFunctions(t *testing.T) {
+	type ArrowFunctionTest struct {
+		input              string
+		expectedParameters []string
+		expected           string
+	}
+	tests := []ArrowFunctionTest{
+		{
+			input:              "x => x * 2",
+			expectedParameters: []string{"x"},
+			expected:           "fn (x) (x * 2)",
+		},
+		{
+			input:              "(x, y) => x + y",
+			expectedParameters: []string{"x", "y"},
+			expected:           "fn (x, y) (x + y)",
+		},
+		{
+			input:              "() => 1",
+			expectedParameters: []string{},
+			expected:           "fn () 1",
+		},
+		{
+			input:              "(x = 1, ...rest) => { let y = x; y }",
+			expectedParameters: []string{"x = 1", "...rest"},
+			expected:           "fn (x = 1, ...rest) let y = x;y",
+		},
+		{
+			input:              "([a, b]) => a",
+			expectedParameters: []string{"[a, b]"},
+			expected:           "fn ([a, b]) a",
+		},
+		{
+			input:              "x => y => x + y",
+			expectedParameters: []string{"x"},
+			expected:           "fn (x) fn (y) (x + y)",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		expressionStatement, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
+		}
+
+		functionLiteral, ok := expressionStatement.Value.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.FunctionLiteral", expressionStatement.Value)
+		}
+
+		if paramsAmount := len(functionLiteral.Parameters); paramsAmount != len(test.expectedParameters) {
+			t.Fatalf("[Test] Invalid function params amount: received %d, expected %d", paramsAmount, len(test.expectedParameters))
+		}
+
+		for i, expectedParameter := range test.expectedParameters {
+			if parameter := functionLiteral.Parameters[i].String(); parameter != expectedParameter {
+				t.Errorf("[Test] Invalid function param: received %s, expected %s", parameter, expectedParameter)
+			}
+		}
+
+		if program.String() != test.expected {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test.expected)
+		}
+	}
+}
+
+func TestFunctionLiteralParsing(t *testing.T) {
+	input := "fn (x, y) { x + y; }"
+
+	lex := lexer.New(input)
+	parser := New(lex)
+	program := parser.ParseProgram()
+	testParserErrors(t, parser)
+
+	expectedStatementAmount := 1
+	if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+		t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+	}
+
+	expressionStatement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
+	}
+
+	functionLiteral, ok := expressionStatement.Value.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.FunctionLiteral", expressionStatement.Value)
+	}
+
+	expectedParamsAmount := 2
+	if paramsAmount := len(functionLiteral.Parameters); paramsAmount != expectedParamsAmount {
+		t.Fatalf("[Test] Invalid function params amount: received %d, expected %d", paramsAmount, expectedParamsAmount)
+	}
+
+	testLiteralExpression(t, functionLiteral.Parameters[0], token.TokenLiteral("x"))
+	testLiteralExpression(t, functionLiteral.Parameters[1], token.TokenLiteral("y"))
+
+	expectedBodyStatementAmount := 1
+	if bodyStatementAmount := len(functionLiteral.Body.Statements); bodyStatementAmount != expectedBodyStatementAmount {
+		t.Fatalf("[Test] Invalid body statement amount: received %d, expected %d", bodyStatementAmount, expectedBodyStatementAmount)
+	}
+
+	bodyStatement, ok := functionLiteral.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("[Test] Invalid body statement type: received %T, expected *ast.ExpressionStatement", functionLiteral.Body.Statements[0])
+	}
+
+	testInfixExpression(t, bodyStatement.Value, "+", token.TokenLiteral("x"), token.TokenLiteral("y"))
+}
+
+func TestNamedFunctions(t *testing.T) {
+	type NamedFunctionTest struct {
+		input             string
+		expectedStatement bool
+		expectedName      string
+		expected          string
+	}
+	tests := []NamedFunctionTest{
+		{
+			input:             "fn add(x, y) { x + y; }",
+			expectedStatement: true,
+			expectedName:      "add",
+			expected:          "fn add(x, y) (x + y)",
+		},
+		{
+			input:             "let fact = fn f(n) { n * f(n - 1) };",
+			expectedStatement: false,
+			expectedName:      "f",
+			expected:          "let fact = fn f(n) (n * f((n - 1)));",
+		},
+		{
+			input:             "fn (x) { x; }(1);",
+			expectedStatement: false,
+			expectedName:      "",
+			expected:          "fn (x) x(1)",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		functionStatement, isStatement := program.Statements[0].(*ast.FunctionStatement)
+		if isStatement != test.expectedStatement {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected function statement %t", program.Statements[0], test.expectedStatement)
+		}
+
+		if isStatement {
+			testLiteralExpression(t, functionStatement.Value.Name, token.TokenLiteral(test.expectedName))
+		}
+
+		if program.String() != test.expected {
+			t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), test.expected)
+		}
+	}
+}
+
+func TestTailCallMarking(t *testing.T) {
+	type TailCallMarkingTest struct {
+		input    string
+		expected []string
+	}
+	tests := []TailCallMarkingTest{
+		{
+			input:    "fn f(n) { g(n); h(n) }",
+			expected: []string{"h(n)"},
+		},
+		{
+			input:    "fn f(n) { if (n == 0) { return a(n); } n * b(n) }",
+			expected: []string{"a(n)"},
+		},
+		{
+			input:    "fn f(n) { if (n) { a(n) } else { b(n) } }",
+			expected: []string{"a(n)", "b(n)"},
+		},
+		{
+			input:    "let f = (n) => n ? a(n) : match (n) { 0 => b(n), _ => { c(n) } };",
+			expected: []string{"a(n)", "b(n)", "c(n)"},
+		},
+		{
+			input:    "fn f(n) { while (n) { return a(n); } for (x in n) { b(x) } }",
+			expected: []string{"a(n)"},
+		},
+		{
+			input:    "fn f(n) { try { return a(n); } catch (e) { b(e) } }",
+			expected: []string{},
+		},
+		{
+			input:    "a(b(c));",
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		tailCalls := []string{}
+		ast.Modify(program, func(node ast.Node) ast.Node {
+			if call, ok := node.(*ast.CallExpression); ok && call.Tail {
+				tailCalls = append(tailCalls, call.String())
+			}
+			return node
+		})
+
+		if !reflect.DeepEqual(tailCalls, test.expected) {
+			t.Errorf("[Test] Invalid tail calls: received %v, expected %v", tailCalls, test.expected)
+		}
+	}
+}
+
+func TestFunctionParametersParsing(t *testing.T) {
+	type FunctionParameterParsingTest struct {
+		input    string
+		expected []token.TokenLiteral
+	}
+	tests := []FunctionParameterParsingTest{
+		{
+			input:    "fn () {}",
+			expected: []token.TokenLiteral{},
+		},
+		{
+			input: "fn (x) {}",
+			expected: []token.TokenLiteral{
+				"x",
+			},
+		},
+		{
+			input: "fn (x, y) {}",
+			expected: []token.TokenLiteral{
+				"x",
+				"y",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+		testParserErrors(t, parser)
+
+		expectedStatementAmount := 1
+		if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+			t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+		}
+
+		expressionStatement, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
+		}
+
+		functionLiteral, ok := expressionStatement.Value.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.FunctionLiteral", expressionStatement.Value)
 		}
 
 		expectedParamsAmount := len(test.expected)
@@ -598,9 +1507,128 @@ func TestFunctionParametersParsing(t *testing.T) {
 			t.Fatalf(" [Test] Invalid params amount: received %d, expected %d", paramsAmount, expectedParamsAmount)
 		}
 
-		for i, expectedParam := range test.expected {
-			testLiteralExpression(t, functionLiteral.Parameters[i], expectedParam)
-		}
+		for i, expectedParam := range test.expected {
+			testLiteralExpression(t, functionLiteral.Parameters[i], expectedParam)
+		}
+	}
+}
+
+func TestFunctionPatternParameters(t *testing.T) {
+	input := "fn([a, ...rest], {\"k\": v}, x) { a }"
+
+	lex := lexer.New(input)
+	parser := New(lex)
+	program := parser.ParseProgram()
+	testParserErrors(t, parser)
+
+	expressionStatement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
+	}
+
+	functionLiteral, ok := expressionStatement.Value.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.FunctionLiteral", expressionStatement.Value)
+	}
+
+	expectedParamsAmount := 3
+	if paramsAmount := len(functionLiteral.Parameters); paramsAmount != expectedParamsAmount {
+		t.Fatalf("[Test] Invalid function params amount: received %d, expected %d", paramsAmount, expectedParamsAmount)
+	}
+
+	if _, ok := functionLiteral.Parameters[0].(*ast.ArrayLiteral); !ok {
+		t.Errorf("[Test] Invalid function param type: received %T, expected *ast.ArrayLiteral", functionLiteral.Parameters[0])
+	}
+	if _, ok := functionLiteral.Parameters[1].(*ast.HashLiteral); !ok {
+		t.Errorf("[Test] Invalid function param type: received %T, expected *ast.HashLiteral", functionLiteral.Parameters[1])
+	}
+	testLiteralExpression(t, functionLiteral.Parameters[2], token.TokenLiteral("x"))
+
+	expected := "fn ([a, ...rest], {\"k\": v}, x) a"
+	if program.String() != expected {
+		t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), expected)
+	}
+}
+
+func TestDefaultAndRestParameters(t *testing.T) {
+	input := "fn(x, y = 10, [a, b] = [1, 2], ...rest) { x }"
+
+	lex := lexer.New(input)
+	parser := New(lex)
+	program := parser.ParseProgram()
+	testParserErrors(t, parser)
+
+	expressionStatement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
+	}
+
+	functionLiteral, ok := expressionStatement.Value.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.FunctionLiteral", expressionStatement.Value)
+	}
+
+	expectedParamsAmount := 4
+	if paramsAmount := len(functionLiteral.Parameters); paramsAmount != expectedParamsAmount {
+		t.Fatalf("[Test] Invalid function params amount: received %d, expected %d", paramsAmount, expectedParamsAmount)
+	}
+
+	defaultParameter, ok := functionLiteral.Parameters[1].(*ast.DefaultParameter)
+	if !ok {
+		t.Fatalf("[Test] Invalid function param type: received %T, expected *ast.DefaultParameter", functionLiteral.Parameters[1])
+	}
+	testLiteralExpression(t, defaultParameter.Name, token.TokenLiteral("y"))
+	testLiteralExpression(t, defaultParameter.Value, 10)
+
+	rest, ok := functionLiteral.Parameters[3].(*ast.SpreadExpression)
+	if !ok {
+		t.Fatalf("[Test] Invalid function param type: received %T, expected *ast.SpreadExpression", functionLiteral.Parameters[3])
+	}
+	testLiteralExpression(t, rest.Value, token.TokenLiteral("rest"))
+
+	expected := "fn (x, y = 10, [a, b] = [1, 2], ...rest) x"
+	if program.String() != expected {
+		t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), expected)
+	}
+}
+
+func TestSpreadAndNamedArguments(t *testing.T) {
+	input := "f(1, ...xs, y: 2 + 3)"
+
+	lex := lexer.New(input)
+	parser := New(lex)
+	program := parser.ParseProgram()
+	testParserErrors(t, parser)
+
+	expressionStatement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
+	}
+
+	callExpression, ok := expressionStatement.Value.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.CallExpression", expressionStatement.Value)
+	}
+
+	expectedArgumentAmount := 3
+	if argumentAmount := len(callExpression.Arguments); argumentAmount != expectedArgumentAmount {
+		t.Fatalf("[Test] Invalid call arguments amount: received %d, expected %d", argumentAmount, expectedArgumentAmount)
+	}
+
+	if _, ok := callExpression.Arguments[1].(*ast.SpreadExpression); !ok {
+		t.Errorf("[Test] Invalid argument type: received %T, expected *ast.SpreadExpression", callExpression.Arguments[1])
+	}
+
+	namedArgument, ok := callExpression.Arguments[2].(*ast.NamedArgument)
+	if !ok {
+		t.Fatalf("[Test] Invalid argument type: received %T, expected *ast.NamedArgument", callExpression.Arguments[2])
+	}
+	testLiteralExpression(t, namedArgument.Name, token.TokenLiteral("y"))
+	testInfixExpression(t, namedArgument.Value, "+", 2, 3)
+
+	expected := "f(1, ...xs, y: (2 + 3))"
+	if program.String() != expected {
+		t.Errorf("[Test] Invalid program string: received %s, expected %s", program.String(), expected)
 	}
 }
 
@@ -737,6 +1765,60 @@ func TestStringLiteralExpressions(t *testing.T) {
 	}
 }
 
+func TestInterpolatedStringExpressions(t *testing.T) {
+	input := "\"Hello ${name}, you have ${len(items) + 1} items\";"
+
+	lex := lexer.New(input)
+	parser := New(lex)
+	program := parser.ParseProgram()
+	testParserErrors(t, parser)
+
+	expectedStatementAmount := 1
+	if statementAmount := len(program.Statements); statementAmount != expectedStatementAmount {
+		t.Fatalf("[Test] Invalid statement amount: received %d, expected %d", statementAmount, expectedStatementAmount)
+	}
+
+	expressionStatement, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("[Test] Invalid statement type: received %T, expected *ast.ExpressionStatement", program.Statements[0])
+	}
+
+	interpolatedString, ok := expressionStatement.Value.(*ast.InterpolatedString)
+	if !ok {
+		t.Fatalf("[Test] Invalid expression type: received %T, expected *ast.InterpolatedString", expressionStatement.Value)
+	}
+
+	expectedPartAmount := 5
+	if partAmount := len(interpolatedString.Parts); partAmount != expectedPartAmount {
+		t.Fatalf("[Test] Invalid part amount: received %d, expected %d", partAmount, expectedPartAmount)
+	}
+
+	for i, expected := range []string{"Hello ", ", you have ", " items"} {
+		stringLiteral, ok := interpolatedString.Parts[i*2].(*ast.StringLiteral)
+		if !ok {
+			t.Fatalf("[Test] Invalid part type: received %T, expected *ast.StringLiteral", interpolatedString.Parts[i*2])
+		}
+
+		if stringLiteral.Value != expected {
+			t.Errorf("[Test] Invalid string literal value: received %s, expected %s", stringLiteral.Value, expected)
+		}
+	}
+
+	testIdentifier(t, interpolatedString.Parts[1], "name")
+
+	if expectedString := "\"Hello ${name}, you have ${(len(items) + 1)} items\""; interpolatedString.String() != expectedString {
+		t.Errorf("[Test] Invalid interpolated string: received %s, expected %s", interpolatedString.String(), expectedString)
+	}
+
+	expectedSpan := token.Span{
+		Start: token.Position{Offset: 0, Line: 1, Column: 1},
+		End:   token.Position{Offset: 49, Line: 1, Column: 50},
+	}
+	if span := interpolatedString.Span(); span != expectedSpan {
+		t.Errorf("[Test] Invalid span: received %v, expected %v", span, expectedSpan)
+	}
+}
+
 func TestArrayLiterals(t *testing.T) {
 	input := "[1, 2 * 2, 3 + 3]"
 
@@ -981,14 +2063,410 @@ func TestMacroLiteralParsing(t *testing.T) {
 	testInfixExpression(t, bodyExpressionStatement.Value, "+", token.TokenLiteral("x"), token.TokenLiteral("y"))
 }
 
+func TestParserDiagnostics(t *testing.T) {
+	type ParserDiagnosticTest struct {
+		input            string
+		expectedCode     diagnostic.Code
+		expectedSpan     token.Span
+		expectedMessage  string
+		expectedExpected []token.TokenType
+		expectedFix      string
+	}
+	tests := []ParserDiagnosticTest{
+		{
+			input:        "let x 5;",
+			expectedCode: diagnostic.INVALID_NEXT_TOKEN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 6, Line: 1, Column: 7},
+				End:   token.Position{Offset: 7, Line: 1, Column: 8},
+			},
+			expectedMessage:  "Invalid next token type: received INT 5, expected ASSIGN",
+			expectedExpected: []token.TokenType{token.ASSIGN},
+			expectedFix:      "=",
+		},
+		{
+			input:        "let x = 5;\n  let = 10;",
+			expectedCode: diagnostic.INVALID_NEXT_TOKEN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 17, Line: 2, Column: 7},
+				End:   token.Position{Offset: 18, Line: 2, Column: 8},
+			},
+			expectedMessage:  "Invalid next token type: received ASSIGN =, expected IDENTIFIER",
+			expectedExpected: []token.TokenType{token.IDENTIFIER},
+		},
+		{
+			input:        "5 + ;",
+			expectedCode: diagnostic.INVALID_PREFIX,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 4, Line: 1, Column: 5},
+				End:   token.Position{Offset: 5, Line: 1, Column: 6},
+			},
+			expectedMessage: "Invalid prefix for SEMICOLON",
+		},
+		{
+			input:        "let x = 5; /* never closed",
+			expectedCode: diagnostic.UNTERMINATED_COMMENT,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 11, Line: 1, Column: 12},
+				End:   token.Position{Offset: 26, Line: 1, Column: 27},
+			},
+			expectedMessage: "Unterminated block comment",
+			expectedFix:     "*/",
+		},
+		{
+			input:        "let x = 5 @ 3;",
+			expectedCode: diagnostic.ILLEGAL_CHARACTER,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 10, Line: 1, Column: 11},
+				End:   token.Position{Offset: 11, Line: 1, Column: 12},
+			},
+			expectedMessage: "Illegal character \"@\"",
+		},
+		{
+			input:        "a + b = 3;",
+			expectedCode: diagnostic.INVALID_ASSIGNMENT,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 0, Line: 1, Column: 1},
+				End:   token.Position{Offset: 5, Line: 1, Column: 6},
+			},
+			expectedMessage: "Invalid assignment target: (a + b)",
+		},
+		{
+			input:        "let x = 1;\nbreak;",
+			expectedCode: diagnostic.OUTSIDE_LOOP,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 11, Line: 2, Column: 1},
+				End:   token.Position{Offset: 16, Line: 2, Column: 6},
+			},
+			expectedMessage: "break outside of loop",
+		},
+		{
+			input:        "while (true) { let f = fn() { continue; }; }",
+			expectedCode: diagnostic.OUTSIDE_LOOP,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 30, Line: 1, Column: 31},
+				End:   token.Position{Offset: 38, Line: 1, Column: 39},
+			},
+			expectedMessage: "continue outside of loop",
+		},
+		{
+			input:        "for (let i = 0 i < 3; i += 1) {}",
+			expectedCode: diagnostic.INVALID_NEXT_TOKEN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 5, Line: 1, Column: 6},
+				End:   token.Position{Offset: 14, Line: 1, Column: 15},
+			},
+			expectedMessage:  "Invalid next token type: received IDENTIFIER i, expected SEMICOLON",
+			expectedExpected: []token.TokenType{token.SEMICOLON},
+			expectedFix:      ";",
+		},
+		{
+			input:        "match (x) { a + 1 => 2 }",
+			expectedCode: diagnostic.INVALID_PATTERN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 12, Line: 1, Column: 13},
+				End:   token.Position{Offset: 17, Line: 1, Column: 18},
+			},
+			expectedMessage: "Invalid pattern: (a + 1)",
+		},
+		{
+			input:        "match (x) { {y: 1} => 2 }",
+			expectedCode: diagnostic.INVALID_PATTERN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 13, Line: 1, Column: 14},
+				End:   token.Position{Offset: 14, Line: 1, Column: 15},
+			},
+			expectedMessage: "Invalid pattern key: y",
+		},
+		{
+			input:        "match (x) { 1 => 2 3 => 4 }",
+			expectedCode: diagnostic.INVALID_NEXT_TOKEN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 19, Line: 1, Column: 20},
+				End:   token.Position{Offset: 20, Line: 1, Column: 21},
+			},
+			expectedMessage:  "Invalid next token type: received INT 3, expected COMMA",
+			expectedExpected: []token.TokenType{token.COMMA},
+			expectedFix:      ",",
+		},
+		{
+			input:        "let [a, ...b, c] = arr;",
+			expectedCode: diagnostic.INVALID_PATTERN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 8, Line: 1, Column: 9},
+				End:   token.Position{Offset: 12, Line: 1, Column: 13},
+			},
+			expectedMessage: "Invalid rest pattern: ...b",
+		},
+		{
+			input:        "let [a + 1] = arr;",
+			expectedCode: diagnostic.INVALID_PATTERN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 5, Line: 1, Column: 6},
+				End:   token.Position{Offset: 10, Line: 1, Column: 11},
+			},
+			expectedMessage: "Invalid pattern: (a + 1)",
+		},
+		{
+			input:        "let m = macro([a]) { a };",
+			expectedCode: diagnostic.INVALID_PATTERN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 14, Line: 1, Column: 15},
+				End:   token.Position{Offset: 16, Line: 1, Column: 17},
+			},
+			expectedMessage: "Invalid macro parameter: [a]",
+		},
+		{
+			input:        "f(y: 1, 2)",
+			expectedCode: diagnostic.INVALID_ARGUMENT,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 8, Line: 1, Column: 9},
+				End:   token.Position{Offset: 9, Line: 1, Column: 10},
+			},
+			expectedMessage: "Positional argument after named argument: 2",
+		},
+		{
+			input:        "fn(...a, b) {}",
+			expectedCode: diagnostic.INVALID_PARAMETER,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 3, Line: 1, Column: 4},
+				End:   token.Position{Offset: 7, Line: 1, Column: 8},
+			},
+			expectedMessage: "Rest parameter must be last: ...a",
+		},
+		{
+			input:        "fn(...[a]) {}",
+			expectedCode: diagnostic.INVALID_PARAMETER,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 3, Line: 1, Column: 4},
+				End:   token.Position{Offset: 8, Line: 1, Column: 9},
+			},
+			expectedMessage: "Invalid rest parameter: ...[a]",
+		},
+		{
+			input:        "while (true) { let f = x => { break; }; }",
+			expectedCode: diagnostic.OUTSIDE_LOOP,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 30, Line: 1, Column: 31},
+				End:   token.Position{Offset: 35, Line: 1, Column: 36},
+			},
+			expectedMessage: "break outside of loop",
+		},
+		{
+			input:        "x?[1] = 2;",
+			expectedCode: diagnostic.INVALID_ASSIGNMENT,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 0, Line: 1, Column: 1},
+				End:   token.Position{Offset: 4, Line: 1, Column: 5},
+			},
+			expectedMessage: "Invalid assignment target: (x?[1])",
+		},
+		{
+			input:        "a ? b;",
+			expectedCode: diagnostic.INVALID_NEXT_TOKEN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 5, Line: 1, Column: 6},
+				End:   token.Position{Offset: 6, Line: 1, Column: 7},
+			},
+			expectedMessage:  "Invalid next token type: received SEMICOLON ;, expected COLON",
+			expectedExpected: []token.TokenType{token.COLON},
+			expectedFix:      ":",
+		},
+		{
+			input:        "try { f(); };",
+			expectedCode: diagnostic.INVALID_NEXT_TOKEN,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 12, Line: 1, Column: 13},
+				End:   token.Position{Offset: 13, Line: 1, Column: 14},
+			},
+			expectedMessage:  "Invalid next token type: received SEMICOLON ;, expected FINALLY",
+			expectedExpected: []token.TokenType{token.FINALLY},
+			expectedFix:      "finally",
+		},
+		{
+			input:        "99999999999999999999",
+			expectedCode: diagnostic.INTEGER_OVERFLOW,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 0, Line: 1, Column: 1},
+				End:   token.Position{Offset: 20, Line: 1, Column: 21},
+			},
+			expectedMessage: "Integer literal 99999999999999999999 overflows int64",
+		},
+		{
+			input:        "let mask = 0xFFFF_FFFF_FFFF_FFFF;",
+			expectedCode: diagnostic.INTEGER_OVERFLOW,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 11, Line: 1, Column: 12},
+				End:   token.Position{Offset: 32, Line: 1, Column: 33},
+			},
+			expectedMessage: "Integer literal 0xFFFF_FFFF_FFFF_FFFF overflows int64",
+		},
+		{
+			input:        "1__000",
+			expectedCode: diagnostic.INVALID_INTEGER,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 0, Line: 1, Column: 1},
+				End:   token.Position{Offset: 6, Line: 1, Column: 7},
+			},
+			expectedMessage: "Invalid token literal. Could not parse 1__000 as int",
+		},
+		{
+			input:        "0b102",
+			expectedCode: diagnostic.INVALID_INTEGER,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 0, Line: 1, Column: 1},
+				End:   token.Position{Offset: 5, Line: 1, Column: 6},
+			},
+			expectedMessage: "Invalid token literal. Could not parse 0b102 as int",
+		},
+		{
+			input:        "let s = \"never closed;",
+			expectedCode: diagnostic.UNTERMINATED_STRING,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 8, Line: 1, Column: 9},
+				End:   token.Position{Offset: 22, Line: 1, Column: 23},
+			},
+			expectedMessage: "Unterminated string literal",
+			expectedFix:     "\"",
+		},
+		{
+			input:        "let s = \"a\\qb\";",
+			expectedCode: diagnostic.INVALID_ESCAPE,
+			expectedSpan: token.Span{
+				Start: token.Position{Offset: 10, Line: 1, Column: 11},
+				End:   token.Position{Offset: 12, Line: 1, Column: 13},
+			},
+			expectedMessage: "Invalid escape sequence \"\\q\"",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		parser.ParseProgram()
+
+		if len(parser.Diagnostics) == 0 {
+			t.Errorf("[Test] Invalid diagnostic amount: received 0, expected at least 1")
+			continue
+		}
+
+		diag := parser.Diagnostics[0]
+
+		if diag.Severity != diagnostic.ERROR {
+			t.Errorf("[Test] Invalid diagnostic severity: received %s, expected %s", diag.Severity, diagnostic.ERROR)
+		}
+
+		if diag.Code != test.expectedCode {
+			t.Errorf("[Test] Invalid diagnostic code: received %s, expected %s", diag.Code, test.expectedCode)
+		}
+
+		if diag.Span != test.expectedSpan {
+			t.Errorf("[Test] Invalid diagnostic span: received %+v, expected %+v", diag.Span, test.expectedSpan)
+		}
+
+		if diag.Message != test.expectedMessage {
+			t.Errorf("[Test] Invalid diagnostic message: received %s, expected %s", diag.Message, test.expectedMessage)
+		}
+
+		if fmt.Sprint(diag.Expected) != fmt.Sprint(test.expectedExpected) {
+			t.Errorf("[Test] Invalid diagnostic expected tokens: received %v, expected %v", diag.Expected, test.expectedExpected)
+		}
+
+		if len(test.expectedFix) > 0 && (diag.Fix == nil || diag.Fix.Replacement != test.expectedFix) {
+			t.Errorf("[Test] Invalid diagnostic fix: received %+v, expected %s", diag.Fix, test.expectedFix)
+		}
+	}
+}
+
+func TestErrorRecovery(t *testing.T) {
+	type ErrorRecoveryTest struct {
+		input              string
+		expectedErrors     []string
+		expectedStatements string
+	}
+	tests := []ErrorRecoveryTest{
+		{
+			input: "let x = 5;\nlet = 10;\nlet y = (1 + ;\nlet z = x * 2;\nreturn x +;\nz;",
+			expectedErrors: []string{
+				"2:5: error[E0001]: Invalid next token type: received ASSIGN =, expected IDENTIFIER",
+				"3:14: error[E0002]: Invalid prefix for SEMICOLON",
+				"5:11: error[E0002]: Invalid prefix for SEMICOLON",
+			},
+			expectedStatements: "let x = 5;let z = (x * 2);z",
+		},
+		{
+			input: "let a = [1, , 3];\nlet b = add(1, 2 +, 3);\nlet c = {\"a\": , \"b\": 2};",
+			expectedErrors: []string{
+				"1:13: error[E0002]: Invalid prefix for COMMA",
+				"2:19: error[E0002]: Invalid prefix for COMMA",
+				"3:15: error[E0002]: Invalid prefix for COMMA",
+			},
+			expectedStatements: "let a = [1, 3];let b = add(1, 3);let c = {\"b\": 2};",
+		},
+		{
+			input: "let f = fn(x, 1, y) {\n\tlet = x;\n\tx + y;\n};\nif (f(1, 2) {\n\ttrue;\n}\nf(1, 2);",
+			expectedErrors: []string{
+				"1:15: error[E0001]: Invalid next token type: received INT 1, expected IDENTIFIER",
+				"2:6: error[E0001]: Invalid next token type: received ASSIGN =, expected IDENTIFIER",
+				"5:13: error[E0001]: Invalid next token type: received LBRACE {, expected RPAREN",
+			},
+			expectedStatements: "let f = fn (x, y) (x + y);f(1, 2)",
+		},
+		{
+			input: "{\"a\": 1 \"b\": 2};\nlet h = {\"c\": 3 \"d\": 4, \"e\": 5};\nh;",
+			expectedErrors: []string{
+				"1:9: error[E0001]: Invalid next token type: received STRING b, expected COMMA",
+				"2:17: error[E0001]: Invalid next token type: received STRING d, expected COMMA",
+			},
+			expectedStatements: "{\"a\": 1, \"b\": 2}let h = {\"c\": 3, \"d\": 4, \"e\": 5};h",
+		},
+		{
+			input: "match (1) { 1 -> 2 };\nlet f = fn(x y) { x };\nlet a = if (x { 1 };\nlet b = 2;",
+			expectedErrors: []string{
+				"1:16: error[E0002]: Invalid prefix for GR",
+				"2:14: error[E0001]: Invalid next token type: received IDENTIFIER y, expected RPAREN",
+				"3:15: error[E0001]: Invalid next token type: received LBRACE {, expected RPAREN",
+			},
+			expectedStatements: "let b = 2;",
+		},
+		{
+			input: "let f = fn() {\n\tlet g = fn(x y) { x };\n\tlet h = match (1) { 1 -> 2 };\n\t1;\n};\nf();",
+			expectedErrors: []string{
+				"2:15: error[E0001]: Invalid next token type: received IDENTIFIER y, expected RPAREN",
+				"3:25: error[E0002]: Invalid prefix for GR",
+			},
+			expectedStatements: "let f = fn () 1;f()",
+		},
+	}
+
+	for _, test := range tests {
+		lex := lexer.New(test.input)
+		parser := New(lex)
+		program := parser.ParseProgram()
+
+		errors := []string{}
+		for _, diag := range parser.Diagnostics {
+			errors = append(errors, diag.Error())
+		}
+
+		if fmt.Sprint(errors) != fmt.Sprint(test.expectedErrors) {
+			t.Errorf("[Test] Invalid errors: received %q, expected %q", errors, test.expectedErrors)
+		}
+
+		if received := program.String(); received != test.expectedStatements {
+			t.Errorf("[Test] Invalid partial program: received %s, expected %s", received, test.expectedStatements)
+		}
+	}
+}
+
 func testParserErrors(t *testing.T, parser *Parser) {
-	errorsAmount := len(parser.Errors)
+	errorsAmount := len(parser.Diagnostics)
 	if errorsAmount == 0 {
 		return
 	}
 	t.Errorf("[Test] Parser encountered %d error(s)", errorsAmount)
-	for _, err := range parser.Errors {
-		t.Error(err)
+	for _, diag := range parser.Diagnostics {
+		t.Error(diag.Error())
 	}
 	t.FailNow()
 }
@@ -1011,8 +2489,14 @@ func testLetStatement(t *testing.T, statement ast.Statement, identifier token.To
 		return false
 	}
 
-	if letStatement.Name.Value != identifier {
-		t.Errorf("[Test] Invalid let statement name value: received %v, expected %v", letStatement.Name.Value, identifier)
+	name, ok := letStatement.Name.(*ast.Identifier)
+	if !ok {
+		t.Errorf("[Test] Invalid let statement name type: received %T, expected *ast.Identifier", letStatement.Name)
+		return false
+	}
+
+	if name.Value != identifier {
+		t.Errorf("[Test] Invalid let statement name value: received %v, expected %v", name.Value, identifier)
 		return false
 	}
 
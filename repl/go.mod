@@ -12,16 +12,17 @@ replace leonardjouve/evaluator => ../evaluator
 
 replace leonardjouve/object => ../object
 
+replace leonardjouve/diagnostic => ../diagnostic
+
 go 1.20
 
 require (
+	leonardjouve/ast v0.0.0-00010101000000-000000000000
+	leonardjouve/diagnostic v0.0.0-00010101000000-000000000000
 	leonardjouve/evaluator v0.0.0-00010101000000-000000000000
 	leonardjouve/lexer v0.0.0-00010101000000-000000000000
+	leonardjouve/object v0.0.0-00010101000000-000000000000
 	leonardjouve/parser v0.0.0-00010101000000-000000000000
 )
 
-require (
-	leonardjouve/ast v0.0.0-00010101000000-000000000000 // indirect
-	leonardjouve/object v0.0.0-00010101000000-000000000000 // indirect
-	leonardjouve/token v0.0.0-00010101000000-000000000000 // indirect
-)
+require leonardjouve/token v0.0.0-00010101000000-000000000000 // indirect
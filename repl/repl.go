@@ -4,10 +4,14 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"leonardjouve/ast"
+	"leonardjouve/diagnostic"
 	"leonardjouve/evaluator"
 	"leonardjouve/lexer"
 	"leonardjouve/object"
 	"leonardjouve/parser"
+	"os"
+	"strings"
 )
 
 const PROMPT = ">> "
@@ -26,28 +30,93 @@ func Start(in io.Reader, out io.Writer) {
 		}
 
 		line := scanner.Text()
-		lex := lexer.New(line)
-		par := parser.New(lex)
-		program := par.ParseProgram()
-
-		if len(par.Errors) > 0 {
-			printParserErrors(out, par.Errors)
+		eval := run("", line, out, env, macroEnv)
+		if eval == nil {
 			continue
 		}
+		io.WriteString(out, inspect(eval)+"\n")
+	}
+}
 
-		evaluator.DefineMacros(program, macroEnv)
-		expanded := evaluator.ExpandMacros(program, macroEnv)
+func RunFile(filename string, out io.Writer) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
 
-		eval := evaluator.Eval(expanded, env)
-		if eval == nil {
-			continue
+	return runReader(filename, file, out, func() (string, error) {
+		source, err := os.ReadFile(filename)
+		return string(source), err
+	})
+}
+
+func Run(in io.Reader, out io.Writer) error {
+	var source strings.Builder
+	return runReader("", io.TeeReader(in, &source), out, func() (string, error) {
+		return source.String(), nil
+	})
+}
+
+func runReader(filename string, reader io.Reader, out io.Writer, readSource func() (string, error)) error {
+	lex := lexer.NewReaderWithFilename(filename, reader)
+	par := parser.New(lex)
+	program := par.ParseProgram()
+	if err := lex.Err(); err != nil {
+		return err
+	}
+
+	if len(par.Diagnostics) > 0 {
+		source, err := readSource()
+		if err != nil {
+			return err
+		}
+		printParserDiagnostics(out, par.Diagnostics, source)
+		if par.HasErrors() {
+			return nil
 		}
-		io.WriteString(out, eval.Inspect()+"\n")
 	}
+
+	env := object.NewEnvironement()
+	macroEnv := object.NewEnvironement()
+	eval := evaluate(program, env, macroEnv)
+	if eval != nil && eval.Type() == object.ERROR {
+		io.WriteString(out, inspect(eval)+"\n")
+	}
+
+	return nil
+}
+
+func run(filename string, source string, out io.Writer, env *object.Environement, macroEnv *object.Environement) object.Object {
+	par := parser.New(lexer.NewWithFilename(filename, source))
+	program := par.ParseProgram()
+
+	if len(par.Diagnostics) > 0 {
+		printParserDiagnostics(out, par.Diagnostics, source)
+		if par.HasErrors() {
+			return nil
+		}
+	}
+
+	return evaluate(program, env, macroEnv)
+}
+
+func evaluate(program *ast.Program, env *object.Environement, macroEnv *object.Environement) object.Object {
+	evaluator.DefineMacros(program, macroEnv)
+	expanded := evaluator.ExpandMacros(program, macroEnv)
+
+	return evaluator.Eval(expanded, env)
+}
+
+func inspect(obj object.Object) string {
+	if err, ok := obj.(*object.Error); ok {
+		return err.Traceback()
+	}
+	return obj.Inspect()
 }
 
-func printParserErrors(out io.Writer, errors []string) {
-	for _, err := range errors {
-		io.WriteString(out, "\t"+err+"\n")
+func printParserDiagnostics(out io.Writer, diagnostics []*diagnostic.Diagnostic, source string) {
+	for _, diag := range diagnostics {
+		io.WriteString(out, diag.Render(source))
 	}
 }
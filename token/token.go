@@ -1,36 +1,81 @@
 package token
 
+import "fmt"
+
 type TokenType string
 type TokenLiteral string
 
+type Position struct {
+	Filename string `json:"filename,omitempty"`
+	Offset   int    `json:"offset"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+type Span struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
 type Token struct {
-	Type    TokenType
-	Literal TokenLiteral
+	Type           TokenType    `json:"type"`
+	Literal        TokenLiteral `json:"literal"`
+	Span           Span         `json:"span"`
+	LeadingTrivia  []Token      `json:"leadingTrivia,omitempty"`
+	TrailingTrivia []Token      `json:"trailingTrivia,omitempty"`
 }
 
 const (
 	ILLEGAL = "ILLEGAL"
 	EOF     = "EOF"
+	COMMENT = "COMMENT"
 
 	IDENTIFIER = "IDENTIFIER"
 	INT        = "INT"
+	FLOAT      = "FLOAT"
 	STRING     = "STRING"
 
+	STRING_HEAD   = "STRING_HEAD"
+	STRING_MIDDLE = "STRING_MIDDLE"
+	STRING_TAIL   = "STRING_TAIL"
+
 	ASSIGN    = "ASSIGN"
 	PLUS      = "PLUS"
 	MINUS     = "MINUS"
 	BANG      = "BANG"
 	ASTERISX  = "ASTERISX"
 	SLASH     = "SLASH"
+	MODULO    = "MODULO"
 	EQUAL     = "EQUAL"
 	NOT_EQUAL = "NOT_EQUAL"
 
-	LR = "LR"
-	GR = "GR"
+	PLUS_ASSIGN     = "PLUS_ASSIGN"
+	MINUS_ASSIGN    = "MINUS_ASSIGN"
+	ASTERISX_ASSIGN = "ASTERISX_ASSIGN"
+	SLASH_ASSIGN    = "SLASH_ASSIGN"
+
+	LR       = "LR"
+	GR       = "GR"
+	LR_EQUAL = "LR_EQUAL"
+	GR_EQUAL = "GR_EQUAL"
+
+	AND = "AND"
+	OR  = "OR"
+
+	BITWISE_AND = "BITWISE_AND"
+	BITWISE_OR  = "BITWISE_OR"
+	BITWISE_XOR = "BITWISE_XOR"
+	LEFT_SHIFT  = "LEFT_SHIFT"
+	RIGHT_SHIFT = "RIGHT_SHIFT"
 
 	COMMA     = "COMMA"
 	COLON     = "COLON"
 	SEMICOLON = "SEMICOLON"
+	ARROW     = "ARROW"
+	ELLIPSIS  = "ELLIPSIS"
+	PIPELINE  = "PIPELINE"
+	QUESTION  = "QUESTION"
+	NULLISH   = "NULLISH"
 
 	LPAREN   = "LPAREN"
 	RPAREN   = "RPAREN"
@@ -39,11 +84,24 @@ const (
 	LBRACKET = "LBRACKET"
 	RBRACKET = "RRACKET"
 
+	OPTIONAL_INDEX = "OPTIONAL_INDEX"
+
 	FUNCTION = "FUNCTION"
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
 	LET      = "LET"
+	CONST    = "CONST"
+	WHILE    = "WHILE"
+	FOR      = "FOR"
+	IN       = "IN"
+	MATCH    = "MATCH"
+	TRY      = "TRY"
+	CATCH    = "CATCH"
+	FINALLY  = "FINALLY"
+	THROW    = "THROW"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
 	TRUE     = "TRUE"
 	FALSE    = "FALSE"
 
@@ -51,14 +109,72 @@ const (
 )
 
 var keywords = map[TokenLiteral]TokenType{
-	"fn":     FUNCTION,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
-	"let":    LET,
-	"true":   TRUE,
-	"false":  FALSE,
-	"macro":  MACRO,
+	"fn":       FUNCTION,
+	"if":       IF,
+	"else":     ELSE,
+	"return":   RETURN,
+	"let":      LET,
+	"const":    CONST,
+	"while":    WHILE,
+	"for":      FOR,
+	"in":       IN,
+	"match":    MATCH,
+	"try":      TRY,
+	"catch":    CATCH,
+	"finally":  FINALLY,
+	"throw":    THROW,
+	"break":    BREAK,
+	"continue": CONTINUE,
+	"true":     TRUE,
+	"false":    FALSE,
+	"macro":    MACRO,
+}
+
+var symbols = map[TokenType]TokenLiteral{
+	ASSIGN:          "=",
+	PLUS_ASSIGN:     "+=",
+	MINUS_ASSIGN:    "-=",
+	ASTERISX_ASSIGN: "*=",
+	SLASH_ASSIGN:    "/=",
+	PLUS:            "+",
+	MINUS:           "-",
+	BANG:            "!",
+	ASTERISX:        "*",
+	SLASH:           "/",
+	MODULO:          "%",
+	EQUAL:           "==",
+	NOT_EQUAL:       "!=",
+	LR:              "<",
+	GR:              ">",
+	LR_EQUAL:        "<=",
+	GR_EQUAL:        ">=",
+	AND:             "&&",
+	OR:              "||",
+	BITWISE_AND:     "&",
+	BITWISE_OR:      "|",
+	BITWISE_XOR:     "^",
+	LEFT_SHIFT:      "<<",
+	RIGHT_SHIFT:     ">>",
+	COMMA:           ",",
+	COLON:           ":",
+	SEMICOLON:       ";",
+	ARROW:           "=>",
+	ELLIPSIS:        "...",
+	PIPELINE:        "|>",
+	QUESTION:        "?",
+	NULLISH:         "??",
+	LPAREN:          "(",
+	RPAREN:          ")",
+	LBRACE:          "{",
+	RBRACE:          "}",
+	LBRACKET:        "[",
+	OPTIONAL_INDEX:  "?[",
+	RBRACKET:        "]",
+}
+
+func GetSymbolFromType(tokenType TokenType) (TokenLiteral, bool) {
+	symbol, ok := symbols[tokenType]
+	return symbol, ok
 }
 
 func GetKeywordFromType(tokenType TokenType) (TokenLiteral, bool) {
@@ -84,3 +200,22 @@ func LookupIdentifier(identifier TokenLiteral) TokenType {
 	}
 	return IDENTIFIER
 }
+
+func (position Position) IsValid() bool {
+	return position.Line > 0
+}
+
+func (position Position) String() string {
+	location := fmt.Sprintf("%d:%d", position.Line, position.Column)
+	if len(position.Filename) > 0 {
+		location = position.Filename + ":" + location
+	}
+	return location
+}
+
+func (span Span) Join(other Span) Span {
+	return Span{
+		Start: span.Start,
+		End:   other.End,
+	}
+}